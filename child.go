@@ -5,17 +5,39 @@ import (
 	"fmt"
 	"runtime/debug"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
-// child represents a supervised child process.
+// child represents one run of a supervised child process.
+// Every start and restart creates a new child, so a *child identifies a run:
+// exits are only acted on if they come from the run the supervisor is tracking.
 type child struct {
 	spec         ChildSpec
 	ctx          context.Context
-	cancel       context.CancelFunc
+	cancel       context.CancelCauseFunc
 	exits        chan *childExit
+	done         chan struct{}
+	returned     chan struct{}   // closed once the child function has returned
+	parentDone   <-chan struct{} // closed once the supervisor run owning the child stops
+	ready        chan struct{}   // closed by Ready
+	readyOnce    sync.Once
+	lastBeat     atomic.Int64  // UnixNano of the last Heartbeat
+	goid         atomic.Uint64 // id of the goroutine running the child, for stack dumps
 	restartCount int
-	mu           sync.RWMutex
-	stopped      bool
+	attempt      int         // 1 for the first run, counting every restart since
+	index        int         // position in Supervisor.children
+	state        ChildState  // owned by the supervisor loop
+	timer        *time.Timer // pending restart while backing off
+	stableTimer  *time.Timer // pending restart count reset (StableAfter)
+	startedAt    time.Time
+	lastErr      error
+	// restartHistory feeds the child's own intensity limit (ChildSpec.Intensity).
+	restartHistory []time.Time
+	mu             sync.RWMutex
+	stopped        bool
+	// exit is the run's outcome, set before done is closed.
+	exit *childExit
 }
 
 // childExit represents the exit of a child process.
@@ -26,16 +48,35 @@ type childExit struct {
 	stackTrace string
 }
 
+// reason classifies an exit the child made on its own.
+func (e *childExit) reason() ExitReason {
+	switch {
+	case e.panic:
+		return ExitPanic
+	case e.err != nil:
+		return ExitError
+	default:
+		return ExitNormal
+	}
+}
+
 // newChild creates a new child with the given specification.
+// The child inherits values from parentCtx but not its cancellation: the supervisor
+// cancels each child explicitly so that shutdown can stop them in order.
 func newChild(spec ChildSpec, parentCtx context.Context, exits chan *childExit) *child {
-	ctx, cancel := context.WithCancel(parentCtx)
-
-	return &child{
-		spec:   spec,
-		ctx:    ctx,
-		cancel: cancel,
-		exits:  exits,
+	c := &child{
+		spec:       spec,
+		exits:      exits,
+		done:       make(chan struct{}),
+		returned:   make(chan struct{}),
+		parentDone: parentCtx.Done(),
+		ready:      make(chan struct{}),
+		attempt:    1,
 	}
+	ctx := context.WithValue(context.WithoutCancel(parentCtx), childKey{}, c)
+	c.ctx, c.cancel = context.WithCancelCause(ctx)
+
+	return c
 }
 
 // start begins executing the child process in a new goroutine.
@@ -44,36 +85,54 @@ func (c *child) start() {
 }
 
 // runWithRecovery runs the child function with panic recovery.
+// done is closed only after the child function has returned and its exit
+// has been reported, so waiting on it means the goroutine is really finished.
 func (c *child) runWithRecovery() {
-	defer func() {
-		if r := recover(); r != nil {
-			stack := string(debug.Stack())
-			err := fmt.Errorf("panic: %v", r)
-
-			c.exits <- &childExit{
-				child:      c,
-				err:        err,
-				panic:      true,
-				stackTrace: stack,
+	defer close(c.done)
+	c.goid.Store(currentGoroutineID())
+
+	exit := &childExit{child: c}
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				exit.err = fmt.Errorf("panic: %v", r)
+				exit.panic = true
+				exit.stackTrace = string(debug.Stack())
 			}
-		}
+		}()
+		exit.err = c.run()
 	}()
+	c.exit = exit
+	close(c.returned)
 
-	err := c.spec.Start(c.ctx)
+	// Once the child has been stopped the supervisor may no longer be reading
+	// exits (e.g. during shutdown), so don't block on a full channel.
+	select {
+	case c.exits <- exit:
+	case <-c.ctx.Done():
+	}
+
+	// The run is over: release its context, so goroutines the child started on it
+	// stop too. Runs that return on their own are never stopped by the supervisor,
+	// which drops or replaces them instead.
+	c.cancel(context.Canceled)
+}
 
-	c.exits <- &childExit{
-		child: c,
-		err:   err,
-		panic: false,
+// run executes the child's Start function, or serves its nested supervisor.
+func (c *child) run() error {
+	if c.spec.Supervisor != nil {
+		return c.spec.Supervisor.serve(c.ctx)
 	}
+	return c.spec.Start(c.ctx)
 }
 
-// stop cancels the child's context, signaling it to shut down.
-func (c *child) stop() {
+// stop cancels the child's context with the given cause, signaling it to shut down.
+func (c *child) stop(cause error) {
+	c.cancelStable()
 	c.mu.Lock()
 	c.stopped = true
 	c.mu.Unlock()
-	c.cancel()
+	c.cancel(cause)
 }
 
 // isStopped returns whether the child has been stopped.
@@ -82,3 +141,36 @@ func (c *child) isStopped() bool {
 	defer c.mu.RUnlock()
 	return c.stopped
 }
+
+// wait blocks until the child's goroutine has returned or ctx is done.
+// It reports whether the goroutine returned.
+func (c *child) wait(ctx context.Context) bool {
+	select {
+	case <-c.done:
+		return true
+	case <-ctx.Done():
+		// Prefer a finished child over an expired deadline.
+		select {
+		case <-c.done:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// cancelRestart cancels a pending backoff restart, if any.
+func (c *child) cancelRestart() {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+}
+
+// cancelStable cancels a pending restart count reset, if any.
+func (c *child) cancelStable() {
+	if c.stableTimer != nil {
+		c.stableTimer.Stop()
+		c.stableTimer = nil
+	}
+}
@@ -0,0 +1,169 @@
+package goverseer
+
+import (
+	"context"
+	"time"
+)
+
+// ChildState describes where a child is in its lifecycle.
+type ChildState int
+
+const (
+	// StateRunning means the child's goroutine is running.
+	StateRunning ChildState = iota
+	// StateRestarting means the child was stopped by its restart strategy
+	// and is waiting to be started again together with a failed sibling.
+	StateRestarting
+	// StateBackingOff means the child failed and is waiting out its backoff delay.
+	StateBackingOff
+	// StateStopped means the child is not running and no restart is pending.
+	StateStopped
+)
+
+// String returns the string representation of a ChildState.
+func (cs ChildState) String() string {
+	switch cs {
+	case StateRunning:
+		return "Running"
+	case StateRestarting:
+		return "Restarting"
+	case StateBackingOff:
+		return "BackingOff"
+	case StateStopped:
+		return "Stopped"
+	default:
+		return "Unknown"
+	}
+}
+
+// ChildInfo is a point-in-time snapshot of a supervised child.
+type ChildInfo struct {
+	// Name is the child's unique name.
+	Name string
+	// Restart is the child's restart type.
+	Restart RestartType
+	// State is the child's current lifecycle state.
+	State ChildState
+	// DependsOn lists the children this child depends on (ChildSpec.DependsOn).
+	DependsOn []string
+	// Supervisor reports whether the child is a nested supervisor.
+	Supervisor bool
+	// Restarts is how many times the child has been restarted by its strategy.
+	Restarts int
+	// Attempt numbers the child's runs, starting at 1 (see Event.Attempt).
+	Attempt int
+	// LastError is the error from the child's most recent abnormal exit, if any.
+	LastError error
+	// StartedAt is when the current (or most recent) instance was started.
+	StartedAt time.Time
+	// Uptime is how long the current instance has been running. Zero unless running.
+	Uptime time.Duration
+}
+
+// ChildCount summarizes a supervisor's children, like OTP's count_children.
+type ChildCount struct {
+	// Specs is the total number of children, whatever their state.
+	Specs int
+	// Active is the number of children that are currently running.
+	Active int
+	// Supervisors is the number of children that are nested supervisors.
+	Supervisors int
+	// Workers is the number of children that are not supervisors.
+	Workers int
+}
+
+// Children returns a snapshot of the supervisor's children in start order.
+// Before Start, children added so far are reported as StateStopped.
+//
+// This operation is safe to call from any goroutine.
+func (s *Supervisor) Children() ([]ChildInfo, error) {
+	return s.ChildrenContext(context.Background())
+}
+
+// ChildrenContext is like Children but gives up when ctx is done, returning ctx's error.
+// It returns ErrSupervisorStopped right away if the supervisor is stopped or stopping.
+func (s *Supervisor) ChildrenContext(ctx context.Context) ([]ChildInfo, error) {
+	s.mu.Lock()
+	if !s.running && !s.stopped {
+		defer s.mu.Unlock()
+		infos := make([]ChildInfo, 0, len(s.specs))
+		for _, spec := range s.specs {
+			infos = append(infos, ChildInfo{
+				Name:       spec.Name,
+				Restart:    spec.Restart,
+				State:      StateStopped,
+				DependsOn:  spec.DependsOn,
+				Supervisor: spec.Supervisor != nil,
+			})
+		}
+		return infos, nil
+	}
+	s.mu.Unlock()
+
+	infos := make(chan []ChildInfo, 1)
+	if err := s.call(ctx, command{action: "children", infos: infos}); err != nil {
+		return nil, err
+	}
+	return <-infos, nil
+}
+
+// Count returns a summary of the supervisor's children.
+//
+// This operation is safe to call from any goroutine.
+func (s *Supervisor) Count() (ChildCount, error) {
+	return s.CountContext(context.Background())
+}
+
+// CountContext is like Count but gives up when ctx is done, returning ctx's error.
+// It returns ErrSupervisorStopped right away if the supervisor is stopped or stopping.
+func (s *Supervisor) CountContext(ctx context.Context) (ChildCount, error) {
+	infos, err := s.ChildrenContext(ctx)
+	if err != nil {
+		return ChildCount{}, err
+	}
+
+	count := ChildCount{Specs: len(infos)}
+	for _, info := range infos {
+		if info.State == StateRunning {
+			count.Active++
+		}
+		if info.Supervisor {
+			count.Supervisors++
+		} else {
+			count.Workers++
+		}
+	}
+	return count, nil
+}
+
+// childInfos builds the snapshot returned by Children. It runs on the supervisor loop.
+func (s *Supervisor) childInfos() []ChildInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	infos := make([]ChildInfo, 0, len(s.children))
+	for _, ch := range s.children {
+		infos = append(infos, ch.info(now))
+	}
+	return infos
+}
+
+// info returns a snapshot of the child as of now.
+func (c *child) info(now time.Time) ChildInfo {
+	info := ChildInfo{
+		Name:       c.spec.Name,
+		Restart:    c.spec.Restart,
+		State:      c.state,
+		DependsOn:  c.spec.DependsOn,
+		Supervisor: c.spec.Supervisor != nil,
+		Restarts:   c.restartCount,
+		Attempt:    c.attempt,
+		LastError:  c.lastErr,
+		StartedAt:  c.startedAt,
+	}
+	if c.state == StateRunning {
+		info.Uptime = now.Sub(c.startedAt)
+	}
+	return info
+}
@@ -0,0 +1,109 @@
+package goverseer
+
+import "errors"
+
+// Decision is what the supervisor does with a child that exited with an error.
+// It is returned by a Classify function and carried by the Unrecoverable and
+// Escalate wrappers.
+type Decision int
+
+const (
+	// DecisionDefault lets the child's RestartType decide.
+	DecisionDefault Decision = iota
+
+	// DecisionRestart restarts the child, whatever its RestartType.
+	DecisionRestart
+
+	// DecisionStop stops restarting the child, whatever its RestartType.
+	// Use it for errors that will never go away on their own, like bad configuration.
+	DecisionStop
+
+	// DecisionEscalate fails the whole supervisor right away with the child's
+	// error, wrapped in ErrEscalated.
+	DecisionEscalate
+)
+
+// String returns the string representation of a Decision.
+func (d Decision) String() string {
+	switch d {
+	case DecisionDefault:
+		return "Default"
+	case DecisionRestart:
+		return "Restart"
+	case DecisionStop:
+		return "Stop"
+	case DecisionEscalate:
+		return "Escalate"
+	default:
+		return "Unknown"
+	}
+}
+
+// decisionError carries a Decision along with the error a child returned.
+type decisionError struct {
+	err      error
+	decision Decision
+}
+
+func (e *decisionError) Error() string {
+	return e.err.Error()
+}
+
+func (e *decisionError) Unwrap() error {
+	return e.err
+}
+
+// Unrecoverable marks err as one that retrying won't fix: a child returning it
+// is not restarted, whatever its RestartType. It returns nil if err is nil.
+//
+// Example:
+//
+//	cfg, err := loadConfig()
+//	if err != nil {
+//	    return goverseer.Unrecoverable(err)
+//	}
+func Unrecoverable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &decisionError{err: err, decision: DecisionStop}
+}
+
+// Escalate marks err as one the supervisor can't handle: a child returning it
+// fails the whole supervisor right away, without waiting for the intensity limit.
+// It returns nil if err is nil.
+//
+// Example:
+//
+//	if errors.Is(err, errDataCorrupted) {
+//	    return goverseer.Escalate(err)
+//	}
+func Escalate(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &decisionError{err: err, decision: DecisionEscalate}
+}
+
+// classify decides what to do with a child that exited with err. The Unrecoverable
+// and Escalate wrappers take precedence, then the child's Classify, then the
+// supervisor's (WithClassifier).
+func (s *Supervisor) classify(ch *child, err error) Decision {
+	if err == nil {
+		return DecisionDefault
+	}
+
+	var de *decisionError
+	if errors.As(err, &de) {
+		return de.decision
+	}
+	if ch.spec.Classify != nil {
+		if d := ch.spec.Classify(err); d != DecisionDefault {
+			return d
+		}
+	}
+	if s.classifier != nil {
+		return s.classifier(err)
+	}
+	return DecisionDefault
+}
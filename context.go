@@ -0,0 +1,82 @@
+package goverseer
+
+import (
+	"context"
+	"time"
+)
+
+// childKey is the context key under which a child's run is stored.
+type childKey struct{}
+
+// supervisorKey is the context key under which a supervisor stores itself, so that
+// nested supervisors can find the supervisor they run under.
+type supervisorKey struct{}
+
+// childFromContext returns the child run that ctx belongs to, if any.
+func childFromContext(ctx context.Context) *child {
+	c, _ := ctx.Value(childKey{}).(*child)
+	return c
+}
+
+// ChildName returns the name of the supervised child that ctx was passed to,
+// or "" if ctx doesn't belong to a child. It's most useful for SimpleOneForOne
+// instances, whose names are generated by StartChild.
+func ChildName(ctx context.Context) string {
+	if c := childFromContext(ctx); c != nil {
+		return c.spec.Name
+	}
+	return ""
+}
+
+// Ready reports that the child ctx was passed to has finished initializing, e.g.
+// once it has opened its listener or connection. Children whose spec sets a
+// StartTimeout must call it; for other children, and outside a child, it does nothing.
+// Calling it more than once is fine.
+//
+// Example:
+//
+//	func server(ctx context.Context) error {
+//	    ln, err := net.Listen("tcp", ":8080")
+//	    if err != nil {
+//	        return err
+//	    }
+//	    goverseer.Ready(ctx)
+//	    return serve(ctx, ln)
+//	}
+func Ready(ctx context.Context) {
+	if c := childFromContext(ctx); c != nil {
+		c.readyOnce.Do(func() { close(c.ready) })
+	}
+}
+
+// Heartbeat tells the watchdog that the child ctx was passed to is still making
+// progress. Children whose spec sets a WatchdogInterval must call it at least
+// that often; for other children, and outside a child, it does nothing.
+//
+// Example:
+//
+//	for job := range jobs {
+//	    goverseer.Heartbeat(ctx)
+//	    process(job)
+//	}
+func Heartbeat(ctx context.Context) {
+	if c := childFromContext(ctx); c != nil {
+		c.lastBeat.Store(time.Now().UnixNano())
+	}
+}
+
+// ChildArgs returns the arguments passed to StartChild for the SimpleOneForOne
+// instance that ctx was passed to, or nil for other children.
+//
+// Example:
+//
+//	func worker(ctx context.Context) error {
+//	    queue := goverseer.ChildArgs(ctx).(chan Job)
+//	    ...
+//	}
+func ChildArgs(ctx context.Context) any {
+	if c := childFromContext(ctx); c != nil {
+		return c.spec.args
+	}
+	return nil
+}
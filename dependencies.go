@@ -0,0 +1,86 @@
+package goverseer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// sortSpecs orders specs so that every child comes after the children it depends
+// on, keeping the declared order otherwise. It returns ErrMissingDependency or
+// ErrDependencyCycle if DependsOn doesn't describe a DAG over specs.
+func sortSpecs(specs []ChildSpec) ([]ChildSpec, error) {
+	names := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		names[spec.Name] = true
+	}
+	for _, spec := range specs {
+		for _, dep := range spec.DependsOn {
+			if !names[dep] {
+				return nil, fmt.Errorf("%w: %s depends on %s", ErrMissingDependency, spec.Name, dep)
+			}
+		}
+	}
+
+	sorted := make([]ChildSpec, 0, len(specs))
+	started := make(map[string]bool, len(specs))
+	remaining := slices.Clone(specs)
+	for len(remaining) > 0 {
+		// Take the first spec whose dependencies have all been placed.
+		i := slices.IndexFunc(remaining, func(spec ChildSpec) bool {
+			return !slices.ContainsFunc(spec.DependsOn, func(dep string) bool { return !started[dep] })
+		})
+		if i < 0 {
+			cycle := make([]string, 0, len(remaining))
+			for _, spec := range remaining {
+				cycle = append(cycle, spec.Name)
+			}
+			return nil, fmt.Errorf("%w among %s", ErrDependencyCycle, strings.Join(cycle, ", "))
+		}
+
+		started[remaining[i].Name] = true
+		sorted = append(sorted, remaining[i])
+		remaining = slices.Delete(remaining, i, i+1)
+	}
+	return sorted, nil
+}
+
+// checkDependencies checks that a child added at runtime only depends on children
+// that exist, and that no existing child depends on it in a way that makes a cycle.
+// s.mu must be held.
+func (s *Supervisor) checkDependencies(spec *ChildSpec) error {
+	if len(spec.DependsOn) == 0 {
+		return nil
+	}
+
+	// Existing children may still depend on a child of this name that was removed.
+	dependents := map[string]bool{spec.Name: true}
+	s.addDependents(dependents)
+
+	for _, dep := range spec.DependsOn {
+		if dependents[dep] {
+			return fmt.Errorf("%w: %s depends on %s, which depends on it", ErrDependencyCycle, spec.Name, dep)
+		}
+		if _, exists := s.childMap[dep]; !exists {
+			return fmt.Errorf("%w: %s depends on %s", ErrMissingDependency, spec.Name, dep)
+		}
+	}
+	return nil
+}
+
+// addDependents adds to selected every child that depends, directly or
+// transitively, on a child in selected. s.mu must be held.
+func (s *Supervisor) addDependents(selected map[string]bool) {
+	for changed := true; changed; {
+		changed = false
+		for _, ch := range s.children {
+			if selected[ch.spec.Name] {
+				continue
+			}
+			if slices.ContainsFunc(ch.spec.DependsOn, func(dep string) bool { return selected[dep] }) {
+				selected[ch.spec.Name] = true
+				changed = true
+			}
+		}
+	}
+}
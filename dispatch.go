@@ -0,0 +1,203 @@
+package goverseer
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultEventBuffer is how many events each handler can fall behind by default.
+const defaultEventBuffer = 100
+
+// OverflowPolicy decides what happens to an event when a handler's queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes the supervisor wait for room in the queue, so no event
+	// is lost but a handler that falls too far behind slows the supervisor down.
+	// This is the default.
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowDropOldest discards the oldest queued event to make room.
+	OverflowDropOldest
+
+	// OverflowDropNewest discards the new event.
+	OverflowDropNewest
+)
+
+// String returns the string representation of an OverflowPolicy.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "Block"
+	case OverflowDropOldest:
+		return "DropOldest"
+	case OverflowDropNewest:
+		return "DropNewest"
+	default:
+		return "Unknown"
+	}
+}
+
+// HandlerStats reports how an event handler is keeping up.
+type HandlerStats struct {
+	// Queued is the number of events waiting to be handled.
+	Queued int
+	// Dropped is the number of events discarded because the queue was full.
+	Dropped uint64
+	// Panics is the number of times the handler panicked.
+	Panics uint64
+}
+
+// dispatcher delivers events to one handler from its own goroutine and queue,
+// so that a slow or panicking handler can't stall or crash the supervisor loop.
+// Its counters live as long as the supervisor; the queue and goroutine are
+// replaced on every run.
+type dispatcher struct {
+	handler EventHandler
+	policy  OverflowPolicy
+	size    int
+
+	mu     sync.Mutex
+	queue  chan Event
+	closed bool
+	done   chan struct{}
+
+	dropped atomic.Uint64
+	panics  atomic.Uint64
+}
+
+// startDispatchers starts delivering events for a new run. s.mu must be held.
+func (s *Supervisor) startDispatchers() {
+	if s.dispatchers == nil {
+		for _, handler := range s.eventHandlers {
+			s.dispatchers = append(s.dispatchers, &dispatcher{
+				handler: handler,
+				policy:  s.eventPolicy,
+				size:    s.eventBuffer,
+			})
+		}
+	}
+
+	for _, d := range s.dispatchers {
+		d.mu.Lock()
+		d.queue = make(chan Event, d.size)
+		d.closed = false
+		d.done = make(chan struct{})
+		go s.deliver(d, d.queue, d.done)
+		d.mu.Unlock()
+	}
+}
+
+// stopDispatchers waits for every handler to work through its queue.
+// It runs on the supervisor loop once nothing else will be emitted.
+func (s *Supervisor) stopDispatchers() {
+	for _, d := range s.dispatchers {
+		d.mu.Lock()
+		d.closed = true
+		close(d.queue)
+		done := d.done
+		d.mu.Unlock()
+		<-done
+	}
+}
+
+// deliver calls d's handler for every event in queue until it is closed.
+func (s *Supervisor) deliver(d *dispatcher, queue chan Event, done chan struct{}) {
+	defer close(done)
+	for e := range queue {
+		s.handle(d, e)
+	}
+}
+
+// handle calls d's handler, reporting a panic to the other handlers.
+func (s *Supervisor) handle(d *dispatcher, e Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			d.panics.Add(1)
+			s.reportHandlerPanic(d, r, string(debug.Stack()))
+		}
+	}()
+	d.handler(e)
+}
+
+// reportHandlerPanic tells every handler but the one that panicked about it.
+// Reports never block, so handlers that keep panicking can't deadlock each other.
+func (s *Supervisor) reportHandlerPanic(from *dispatcher, r any, stack string) {
+	e := Event{
+		Time:       time.Now(),
+		Type:       HandlerPanicked,
+		Err:        fmt.Errorf("event handler panicked: %v", r),
+		StackTrace: stack,
+		Supervisor: s.name,
+		Path:       s.path,
+	}
+
+	if s.journal != nil {
+		s.journal.add(e)
+	}
+	for _, d := range s.dispatchers {
+		if d == from {
+			continue
+		}
+		d.mu.Lock()
+		if !d.closed {
+			select {
+			case d.queue <- e:
+			default:
+				d.dropped.Add(1)
+			}
+		}
+		d.mu.Unlock()
+	}
+	s.publish(e)
+}
+
+// send queues e for d according to its overflow policy. It runs on the supervisor loop.
+func (d *dispatcher) send(e Event) {
+	switch d.policy {
+	case OverflowDropNewest:
+		select {
+		case d.queue <- e:
+		default:
+			d.dropped.Add(1)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case d.queue <- e:
+				return
+			default:
+			}
+			select {
+			case <-d.queue:
+				d.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		d.queue <- e
+	}
+}
+
+// EventStats reports on each event handler, in the order they were registered.
+//
+// This operation is safe to call from any goroutine.
+func (s *Supervisor) EventStats() []HandlerStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := make([]HandlerStats, 0, len(s.dispatchers))
+	for _, d := range s.dispatchers {
+		d.mu.Lock()
+		stats = append(stats, HandlerStats{
+			Queued:  len(d.queue),
+			Dropped: d.dropped.Load(),
+			Panics:  d.panics.Load(),
+		})
+		d.mu.Unlock()
+	}
+	return stats
+}
@@ -1,21 +1,101 @@
 package goverseer
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 var (
 	// ErrSupervisorStopped is returned when operations are attempted on a stopped supervisor.
 	ErrSupervisorStopped = errors.New("supervisor is stopped")
 
+	// ErrSupervisorRunning is returned when starting a supervisor that is already running,
+	// either on its own or as the child of another supervisor.
+	ErrSupervisorRunning = errors.New("supervisor is already running")
+
+	// ErrInvalidChildSpec is returned when a ChildSpec sets neither or both of Start and Supervisor.
+	ErrInvalidChildSpec = errors.New("child spec must set exactly one of Start or Supervisor")
+
+	// ErrSignificantPermanent is returned when a ChildSpec is both Significant and Permanent.
+	// A Permanent child never exits for good, so it can't trigger an automatic shutdown.
+	ErrSignificantPermanent = errors.New("significant child must be Transient or Temporary")
+
+	// ErrNoTemplate is returned by StartChild when the supervisor isn't a SimpleOneForOne
+	// supervisor with a template.
+	ErrNoTemplate = errors.New("supervisor has no SimpleOneForOne child template")
+
 	// ErrIntensityExceeded is returned when restart intensity limits are exceeded.
 	// This indicates too many restarts occurred in the configured time window.
 	ErrIntensityExceeded = errors.New("restart intensity exceeded")
 
+	// ErrEscalated is returned from Wait when a child's error was escalated to
+	// the supervisor (see Escalate and DecisionEscalate). The child's error is wrapped too.
+	ErrEscalated = errors.New("child error escalated")
+
 	// ErrChildNotFound is returned when a child with the given name doesn't exist.
 	ErrChildNotFound = errors.New("child not found")
 
 	// ErrChildAlreadyExists is returned when adding a child with a name that's already in use.
 	ErrChildAlreadyExists = errors.New("child already exists")
 
+	// ErrMissingDependency is returned when a child depends on a child that doesn't exist.
+	ErrMissingDependency = errors.New("child depends on a missing child")
+
+	// ErrDependencyCycle is returned when children depend on each other in a cycle.
+	ErrDependencyCycle = errors.New("child dependencies form a cycle")
+
+	// ErrShutdownTimeout is matched by ShutdownTimeoutError when children miss their shutdown deadline.
+	ErrShutdownTimeout = errors.New("children did not stop within the shutdown timeout")
+
+	// ErrStartTimeout is returned when a child with a StartTimeout doesn't call Ready in time.
+	ErrStartTimeout = errors.New("child did not become ready within its start timeout")
+
+	// ErrExitedBeforeReady is returned when a child with a StartTimeout returns or
+	// panics before calling Ready. It is joined with the child's own error, if any.
+	ErrExitedBeforeReady = errors.New("child exited before it became ready")
+
+	// ErrUnhealthy is the error a child fails with when it keeps failing its HealthCheck.
+	// It is also the cause of the child's context.
+	ErrUnhealthy = errors.New("child failed its health check")
+
+	// ErrChildHung is the error a child fails with when it stops calling Heartbeat
+	// (see ChildSpec.WatchdogInterval). It is also the cause of the child's context.
+	ErrChildHung = errors.New("child missed its heartbeat")
+
 	// ErrInvalidShutdownTimeout is returned when shutdown timeout is invalid.
 	ErrInvalidShutdownTimeout = errors.New("shutdown timeout must be positive")
 )
+
+// Cancellation causes. A child can tell why it is being stopped with
+// context.Cause(ctx), e.g. to drain in-flight work on shutdown but drop it on restart.
+var (
+	// ErrShutdown is the cause when the child's supervisor is shutting down.
+	ErrShutdown = errors.New("supervisor shutting down")
+
+	// ErrSiblingFailed is the cause when the child is restarted because a sibling
+	// failed (OneForAll and RestForOne).
+	ErrSiblingFailed = errors.New("sibling failed")
+
+	// ErrManualRestart is the cause when the child is restarted with RestartChild.
+	ErrManualRestart = errors.New("child restarted manually")
+
+	// ErrChildRemoved is the cause when the child is removed with RemoveChild.
+	ErrChildRemoved = errors.New("child removed")
+)
+
+// ShutdownTimeoutError is returned from Stop and Wait when some children were still
+// running after the shutdown timeout. Their goroutines are abandoned.
+// It matches ErrShutdownTimeout with errors.Is.
+type ShutdownTimeoutError struct {
+	// Children lists the names of the children that missed the deadline.
+	Children []string
+}
+
+func (e *ShutdownTimeoutError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrShutdownTimeout, strings.Join(e.Children, ", "))
+}
+
+func (e *ShutdownTimeoutError) Unwrap() error {
+	return ErrShutdownTimeout
+}
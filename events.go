@@ -8,7 +8,8 @@ type EventType int
 const (
 	// ChildStarted is emitted when a child process starts.
 	ChildStarted EventType = iota
-	// ChildExited is emitted when a child process exits normally.
+	// ChildExited is emitted when a child process exits on its own.
+	// Event.Reason tells whether it returned nil or an error.
 	ChildExited
 	// ChildRestarted is emitted when a child process is restarted.
 	ChildRestarted
@@ -18,6 +19,34 @@ const (
 	SupervisorFailedIntensity
 	// ChildPanicked is emitted when a child process panics.
 	ChildPanicked
+	// ChildShutdownTimeout is emitted when a child does not stop within the shutdown timeout.
+	ChildShutdownTimeout
+	// ChildFailedIntensity is emitted when a child exceeds its own restart intensity (ChildSpec.Intensity).
+	ChildFailedIntensity
+	// ChildStable is emitted when a restarted child has run long enough (see WithStableAfter)
+	// for its restart count and backoff to reset.
+	ChildStable
+	// ChildHealthCheckFailed is emitted each time a child's HealthCheck fails.
+	ChildHealthCheckFailed
+	// ChildHealthCheckRecovered is emitted when a child's HealthCheck passes
+	// again after failing.
+	ChildHealthCheckRecovered
+	// ChildHung is emitted when a child misses its heartbeat. StackTrace holds
+	// the stack of the child's goroutine at that moment.
+	ChildHung
+	// BackoffScheduled is emitted when a failed child's restart is delayed by its
+	// backoff policy. Event.Delay holds the delay.
+	BackoffScheduled
+	// ChildStopped is emitted when a child the supervisor stopped (on shutdown,
+	// removal, or restart) has returned, or was abandoned with ShutdownBrutalKill.
+	ChildStopped
+	// HandlerPanicked is emitted when an event handler panics. It is delivered to
+	// the other handlers; StackTrace holds the handler's stack.
+	HandlerPanicked
+	// ChildRemoved is emitted when the supervisor stops tracking a child: it was
+	// removed with RemoveChild, did not become ready when added, or exited and
+	// won't be restarted. A pending restart of the child is canceled.
+	ChildRemoved
 )
 
 // String returns the string representation of an EventType.
@@ -35,11 +64,75 @@ func (et EventType) String() string {
 		return "SupervisorFailedIntensity"
 	case ChildPanicked:
 		return "ChildPanicked"
+	case ChildShutdownTimeout:
+		return "ChildShutdownTimeout"
+	case ChildFailedIntensity:
+		return "ChildFailedIntensity"
+	case ChildStable:
+		return "ChildStable"
+	case ChildHealthCheckFailed:
+		return "ChildHealthCheckFailed"
+	case ChildHealthCheckRecovered:
+		return "ChildHealthCheckRecovered"
+	case ChildHung:
+		return "ChildHung"
+	case BackoffScheduled:
+		return "BackoffScheduled"
+	case ChildStopped:
+		return "ChildStopped"
+	case HandlerPanicked:
+		return "HandlerPanicked"
+	case ChildRemoved:
+		return "ChildRemoved"
 	default:
 		return "Unknown"
 	}
 }
 
+// ExitReason describes why a child exited.
+type ExitReason int
+
+const (
+	// ExitNone is the reason on events that don't report an exit.
+	ExitNone ExitReason = iota
+	// ExitNormal means the child returned nil on its own.
+	ExitNormal
+	// ExitError means the child returned an error on its own.
+	ExitError
+	// ExitPanic means the child panicked.
+	ExitPanic
+	// ExitShutdown means the supervisor stopped the child and it returned in time.
+	ExitShutdown
+	// ExitKilled means the supervisor stopped the child without waiting for it
+	// (ShutdownBrutalKill).
+	ExitKilled
+	// ExitTimeout means the supervisor stopped the child but it did not return
+	// within its shutdown timeout, so its goroutine was abandoned.
+	ExitTimeout
+)
+
+// String returns the string representation of an ExitReason.
+func (r ExitReason) String() string {
+	switch r {
+	case ExitNone:
+		return "none"
+	case ExitNormal:
+		return "normal"
+	case ExitError:
+		return "error"
+	case ExitPanic:
+		return "panic"
+	case ExitShutdown:
+		return "shutdown"
+	case ExitKilled:
+		return "killed"
+	case ExitTimeout:
+		return "timeout"
+	default:
+		return "unknown"
+	}
+}
+
 // Event represents a supervisor lifecycle event.
 // Events are emitted for significant state changes and can be used
 // for logging, metrics collection, and monitoring.
@@ -52,24 +145,64 @@ type Event struct {
 	Type EventType
 	// Err is any error associated with the event (if applicable).
 	Err error
-	// StackTrace contains the panic stack trace for ChildPanicked events.
+	// StackTrace contains the panic stack trace for ChildPanicked events, and the
+	// stuck goroutine's stack for ChildHung events.
 	StackTrace string
+	// Reason is why the child exited, for ChildExited, ChildStopped, ChildPanicked
+	// and ChildShutdownTimeout events. It is ExitNone for other events.
+	Reason ExitReason
+	// Supervisor is the name of the supervisor that emitted the event.
+	Supervisor string
+	// Path is the names of the supervisors from the root of the tree down to
+	// the one that emitted the event, joined with "/", e.g. "app/workers".
+	Path string
+	// Restarts is the child's restart count, which resets once it is stable.
+	Restarts int
+	// Attempt numbers the child's runs, starting at 1; it counts every restart
+	// since the child was added and never resets.
+	Attempt int
+	// Uptime is how long the child had been running, for events reporting its exit.
+	Uptime time.Duration
+	// Delay is the backoff delay before the restart, for BackoffScheduled events.
+	Delay time.Duration
+	// Strategy names the restart strategy applied, for BackoffScheduled and
+	// ChildRestarted events.
+	Strategy string
 }
 
 // EventHandler is a function that processes supervisor events.
 // Multiple handlers can be registered with WithEventHandler.
-// Handlers should return quickly to avoid blocking the supervisor.
+// Each handler runs on its own goroutine and receives events in order through
+// its own queue (see WithEventBuffer), so a slow handler only delays itself until
+// its queue fills up. A panicking handler is recovered and reported to the other
+// handlers as a HandlerPanicked event. Stop and Wait return once every handler has
+// caught up, so handlers must not call them.
 type EventHandler func(e Event)
 
-// emitEvent sends an event to all registered event handlers.
+// emitChildEvent emits e about ch, filling in the child's details.
+func (s *Supervisor) emitChildEvent(ch *child, e Event) {
+	e.ChildName = ch.spec.Name
+	e.Restarts = ch.restartCount
+	e.Attempt = ch.attempt
+	if e.Reason != ExitNone {
+		e.Uptime = time.Since(ch.startedAt)
+	}
+	s.emitEvent(e)
+}
+
+// emitEvent queues an event for all registered event handlers.
 func (s *Supervisor) emitEvent(e Event) {
 	if e.Time.IsZero() {
 		e.Time = time.Now()
 	}
+	e.Supervisor = s.name
+	e.Path = s.path
 
-	for _, handler := range s.eventHandlers {
-		// Call handlers inline - they should be fast
-		// For slow handlers, users should use buffered channels
-		handler(e)
+	if s.journal != nil {
+		s.journal.add(e)
+	}
+	for _, d := range s.dispatchers {
+		d.send(e)
 	}
+	s.publish(e)
 }
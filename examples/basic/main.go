@@ -2,29 +2,31 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"github.com/Gappylul/goverseer"
+	"github.com/Gappylul/goverseer/slogevents"
 )
 
 func basicWorker(ctx context.Context) error {
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
 
-	fmt.Println("Basic worker started")
+	logger := slogevents.FromContext(ctx)
+	logger.Info("Basic worker started")
 
 	for {
 		select {
 		case <-ctx.Done():
-			fmt.Println("Basic worker shutting down")
+			logger.Info("Basic worker shutting down")
 			return nil
 		case <-ticker.C:
-			fmt.Println("Basic worker: tick")
+			logger.Info("Basic worker: tick")
 		}
 	}
 }
@@ -33,9 +35,8 @@ func main() {
 	sup := goverseer.New(
 		goverseer.OneForOne,
 		goverseer.WithName("basic-example"),
-		goverseer.WithEventHandler(func(e goverseer.Event) {
-			log.Printf("[%s] %s", e.Type, e.ChildName)
-		}),
+		goverseer.WithEventHandler(slogevents.Handler(slog.Default())),
+		slogevents.WithChildLogger(slog.Default()),
 		goverseer.WithChildren(
 			goverseer.ChildSpec{
 				Name:    "worker-1",
@@ -113,39 +113,39 @@ func main() {
 		),
 	)
 
-	// Create root supervisor managing all subsystems
+	// Create root supervisor managing all subsystems.
+	// Nested supervisors are started with the root's context, so stopping
+	// the root shuts down the whole tree.
 	root := goverseer.New(
 		goverseer.OneForOne, // Subsystems are independent
 		goverseer.WithName("root-supervisor"),
 		goverseer.WithEventHandler(func(e goverseer.Event) {
 			log.Printf("[%s] %s: %s", e.Type, e.ChildName, e.Type.String())
 		}),
+		goverseer.WithChildren(
+			goverseer.ChildSpec{
+				Name:       "http-subsystem",
+				Supervisor: httpSup,
+				Restart:    goverseer.Permanent,
+			},
+			goverseer.ChildSpec{
+				Name:       "database-subsystem",
+				Supervisor: dbSup,
+				Restart:    goverseer.Permanent,
+			},
+			goverseer.ChildSpec{
+				Name:       "metrics-subsystem",
+				Supervisor: metricsSup,
+				Restart:    goverseer.Permanent,
+			},
+		),
 	)
 
-	// Start all subsystems
+	// Start the whole tree
 	if err := root.Start(); err != nil {
 		log.Fatal(err)
 	}
 
-	// Add supervisors as children
-	root.AddChild(goverseer.ChildSpec{
-		Name:    "http-subsystem",
-		Start:   func(ctx context.Context) error { httpSup.Start(); return httpSup.Wait() },
-		Restart: goverseer.Permanent,
-	})
-
-	root.AddChild(goverseer.ChildSpec{
-		Name:    "database-subsystem",
-		Start:   func(ctx context.Context) error { dbSup.Start(); return dbSup.Wait() },
-		Restart: goverseer.Permanent,
-	})
-
-	root.AddChild(goverseer.ChildSpec{
-		Name:    "metrics-subsystem",
-		Start:   func(ctx context.Context) error { metricsSup.Start(); return metricsSup.Wait() },
-		Restart: goverseer.Permanent,
-	})
-
 	log.Println("Application started with supervision tree:")
 	log.Println("Root")
 	log.Println("├── HTTP (Server + HealthCheck)")
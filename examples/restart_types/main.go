@@ -43,7 +43,7 @@ func main() {
 			case goverseer.ChildStarted:
 				log.Printf("✓ %s started", e.ChildName)
 			case goverseer.ChildExited:
-				log.Printf("✗ %s exited", e.ChildName)
+				log.Printf("✗ %s exited (%s)", e.ChildName, e.Reason)
 			case goverseer.ChildRestarted:
 				log.Printf("↻ %s restarted", e.ChildName)
 			}
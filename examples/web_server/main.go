@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -26,14 +27,20 @@ func httpServerWorker(ctx context.Context) error {
 	})
 
 	server := &http.Server{
-		Addr:    ":8080",
 		Handler: mux,
 	}
 
-	// Start server in goroutine
+	// Bind the port before reporting ready, so Start fails if it's taken
+	ln, err := net.Listen("tcp", ":8080")
+	if err != nil {
+		return err
+	}
+	log.Println("HTTP Server: Listening on :8080")
+	goverseer.Ready(ctx)
+
+	// Serve in goroutine
 	go func() {
-		log.Println("HTTP Server: Listening on :8080")
-		if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		if err := server.Serve(ln); err != http.ErrServerClosed {
 			log.Printf("HTTP Server error: %v", err)
 		}
 	}()
@@ -94,9 +101,10 @@ func main() {
 		}),
 		goverseer.WithChildren(
 			goverseer.ChildSpec{
-				Name:    "http-server",
-				Start:   httpServerWorker,
-				Restart: goverseer.Permanent,
+				Name:         "http-server",
+				Start:        httpServerWorker,
+				Restart:      goverseer.Permanent,
+				StartTimeout: 5 * time.Second,
 			},
 			goverseer.ChildSpec{
 				Name:    "request-logger",
@@ -17,24 +17,25 @@ type Job struct {
 
 var jobQueue = make(chan Job, 100)
 
-// Worker that processes jobs from a queue
-func worker(id int) goverseer.ChildFunc {
-	return func(ctx context.Context) error {
-		fmt.Printf("Worker %d: Started\n", id)
-
-		for {
-			select {
-			case <-ctx.Done():
-				fmt.Printf("Worker %d: Shutting down\n", id)
-				return nil
-
-			case job := <-jobQueue:
-				// Simulate work
-				duration := time.Duration(rand.Intn(1000)) * time.Millisecond
-				fmt.Printf("Worker %d: Processing job %d (takes %v)\n", id, job.ID, duration)
-				time.Sleep(duration)
-				fmt.Printf("Worker %d: Completed job %d\n", id, job.ID)
-			}
+// Worker that processes jobs from the queue passed to StartChild
+func worker(ctx context.Context) error {
+	id := goverseer.ChildName(ctx)
+	jobs := goverseer.ChildArgs(ctx).(chan Job)
+
+	fmt.Printf("%s: Started\n", id)
+
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Printf("%s: Shutting down\n", id)
+			return nil
+
+		case job := <-jobs:
+			// Simulate work
+			duration := time.Duration(rand.Intn(1000)) * time.Millisecond
+			fmt.Printf("%s: Processing job %d (takes %v)\n", id, job.ID, duration)
+			time.Sleep(duration)
+			fmt.Printf("%s: Completed job %d\n", id, job.ID)
 		}
 	}
 }
@@ -59,11 +60,15 @@ func producer(ctx context.Context) error {
 }
 
 func main() {
-	rand.Seed(time.Now().UnixNano())
-
-	sup := goverseer.New(
+	// The pool starts every worker from the same template
+	pool := goverseer.New(
 		goverseer.SimpleOneForOne,
 		goverseer.WithName("worker-pool"),
+		goverseer.WithTemplate(goverseer.ChildSpec{
+			Name:    "worker",
+			Start:   worker,
+			Restart: goverseer.Permanent,
+		}),
 		goverseer.WithEventHandler(func(e goverseer.Event) {
 			if e.Type == goverseer.ChildRestarted {
 				log.Printf("Worker crashed and restarted: %s", e.ChildName)
@@ -71,24 +76,35 @@ func main() {
 		}),
 	)
 
+	sup := goverseer.New(
+		goverseer.OneForOne,
+		goverseer.WithName("app"),
+		goverseer.WithChildren(
+			goverseer.ChildSpec{
+				Name:    "producer",
+				Start:   producer,
+				Restart: goverseer.Permanent,
+			},
+			goverseer.ChildSpec{
+				Name:       "worker-pool",
+				Supervisor: pool,
+				Restart:    goverseer.Permanent,
+			},
+		),
+	)
+
 	if err := sup.Start(); err != nil {
 		log.Fatal(err)
 	}
 
-	// Add producer
-	sup.AddChild(goverseer.ChildSpec{
-		Name:    "producer",
-		Start:   producer,
-		Restart: goverseer.Permanent,
-	})
-
 	// Add 5 workers dynamically
+	var workers []string
 	for i := 1; i <= 5; i++ {
-		sup.AddChild(goverseer.ChildSpec{
-			Name:    fmt.Sprintf("worker-%d", i),
-			Start:   worker(i),
-			Restart: goverseer.Permanent,
-		})
+		id, err := pool.StartChild(jobQueue)
+		if err != nil {
+			log.Fatal(err)
+		}
+		workers = append(workers, id)
 	}
 
 	log.Println("Worker pool started with 5 workers")
@@ -99,20 +115,20 @@ func main() {
 
 	// Scale up - add 3 more workers
 	log.Println("\n🔼 Scaling up: Adding 3 more workers")
-	for i := 6; i <= 8; i++ {
-		sup.AddChild(goverseer.ChildSpec{
-			Name:    fmt.Sprintf("worker-%d", i),
-			Start:   worker(i),
-			Restart: goverseer.Permanent,
-		})
+	for i := 0; i < 3; i++ {
+		id, err := pool.StartChild(jobQueue)
+		if err != nil {
+			log.Fatal(err)
+		}
+		workers = append(workers, id)
 	}
 
 	time.Sleep(10 * time.Second)
 
 	// Scale down - remove some workers
 	log.Println("\n🔽 Scaling down: Removing 4 workers")
-	for i := 5; i <= 8; i++ {
-		sup.RemoveChild(fmt.Sprintf("worker-%d", i))
+	for _, id := range workers[4:] {
+		pool.RemoveChild(id)
 	}
 
 	time.Sleep(10 * time.Second)
@@ -0,0 +1,202 @@
+package goverseer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultHealthInterval         = 10 * time.Second
+	defaultHealthFailureThreshold = 3
+)
+
+// monitorHealth runs ch's HealthCheck until the child stops, returns, or becomes
+// unhealthy, or the supervisor stops.
+// Probes run in their own goroutine so a slow check never blocks the supervisor;
+// results that change something are handed to the loop.
+func (s *Supervisor) monitorHealth(ch *child) {
+	interval := ch.spec.HealthInterval
+	if interval <= 0 {
+		interval = defaultHealthInterval
+	}
+	timeout := ch.spec.HealthTimeout
+	if timeout <= 0 {
+		timeout = interval
+	}
+	threshold := ch.spec.HealthFailureThreshold
+	if threshold <= 0 {
+		threshold = defaultHealthFailureThreshold
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	failures := 0
+	for {
+		select {
+		case <-ch.ctx.Done():
+			return
+		case <-ch.returned:
+			return
+		case <-ch.parentDone:
+			return
+		case <-ticker.C:
+		}
+
+		err := probe(ch, timeout)
+		if ch.ctx.Err() != nil {
+			// Stopped while probing; the failure says nothing about its health.
+			return
+		}
+
+		switch {
+		case err != nil:
+			failures++
+			unhealthy := failures >= threshold
+			s.post(ch, func() error {
+				return s.healthCheckFailed(ch, err, unhealthy)
+			})
+			if unhealthy {
+				return
+			}
+		case failures > 0:
+			failures = 0
+			s.post(ch, func() error {
+				s.healthCheckRecovered(ch)
+				return nil
+			})
+		}
+	}
+}
+
+// probe runs one HealthCheck bounded by timeout.
+func probe(ch *child, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ch.ctx, timeout)
+	defer cancel()
+	return ch.spec.HealthCheck(ctx)
+}
+
+// post hands fn to the supervisor loop on behalf of ch. It gives up once ch is
+// stopped or has returned, or the supervisor has stopped, since the loop may no
+// longer be reading by then and fn would be moot anyway.
+func (s *Supervisor) post(ch *child, fn func() error) {
+	select {
+	case s.deferred <- fn:
+	case <-ch.ctx.Done():
+	case <-ch.returned:
+	case <-ch.parentDone:
+	}
+}
+
+// healthCheckFailed reports a failed probe and, once the child is unhealthy,
+// fails it so that it is restarted by the strategy.
+func (s *Supervisor) healthCheckFailed(ch *child, err error, unhealthy bool) error {
+	if !s.isCurrent(ch) {
+		return nil
+	}
+
+	s.emitChildEvent(ch, Event{
+		Type: ChildHealthCheckFailed,
+		Err:  err,
+	})
+
+	if !unhealthy {
+		return nil
+	}
+	return s.failChild(ch, fmt.Errorf("%w: %w", ErrUnhealthy, err), ch.exits)
+}
+
+// healthCheckRecovered reports a passing probe after failed ones.
+func (s *Supervisor) healthCheckRecovered(ch *child) {
+	if !s.isCurrent(ch) {
+		return
+	}
+
+	s.emitChildEvent(ch, Event{Type: ChildHealthCheckRecovered})
+}
+
+// watchdog watches ch's heartbeats until the child stops, returns, or misses one,
+// or the supervisor stops.
+func (s *Supervisor) watchdog(ch *child) {
+	interval := ch.spec.WatchdogInterval
+	ch.lastBeat.Store(time.Now().UnixNano())
+
+	// Check a few times per interval so a hang is noticed soon after the deadline.
+	ticker := time.NewTicker(max(interval/4, time.Nanosecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ch.ctx.Done():
+			return
+		case <-ch.returned:
+			return
+		case <-ch.parentDone:
+			return
+		case <-ticker.C:
+		}
+
+		if time.Since(time.Unix(0, ch.lastBeat.Load())) <= interval {
+			continue
+		}
+
+		stack := goroutineStack(ch.goid.Load())
+		s.post(ch, func() error {
+			return s.childHung(ch, stack)
+		})
+		return
+	}
+}
+
+// childHung reports a child that missed its heartbeat and fails it, so that it
+// is canceled and replaced.
+func (s *Supervisor) childHung(ch *child, stack string) error {
+	if !s.isCurrent(ch) {
+		return nil
+	}
+
+	s.emitChildEvent(ch, Event{
+		Type:       ChildHung,
+		Err:        ErrChildHung,
+		StackTrace: stack,
+	})
+	return s.failChild(ch, ErrChildHung, ch.exits)
+}
+
+// currentGoroutineID returns the id of the calling goroutine, parsed from the
+// "goroutine N [status]:" header of its stack.
+func currentGoroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	id, _, _ := bytes.Cut(buf, []byte(" "))
+	n, _ := strconv.ParseUint(string(id), 10, 64)
+	return n
+}
+
+// goroutineStack returns the stack of the goroutine with the given id, or ""
+// if it has exited.
+func goroutineStack(id uint64) string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	header := fmt.Sprintf("goroutine %d ", id)
+	for stack := range strings.SplitSeq(string(buf), "\n\n") {
+		if strings.HasPrefix(stack, header) {
+			return stack
+		}
+	}
+	return ""
+}
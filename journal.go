@@ -0,0 +1,90 @@
+package goverseer
+
+import (
+	"sync"
+	"time"
+)
+
+// HistoryQuery selects events from the journal. Empty fields match everything.
+type HistoryQuery struct {
+	// Types lists the event types to return.
+	Types []EventType
+	// Child is a path.Match pattern for the child name, e.g. "worker-*".
+	Child string
+	// Since excludes events before this time.
+	Since time.Time
+	// Until excludes events after this time.
+	Until time.Time
+}
+
+// journal is a ring buffer holding the most recent events.
+type journal struct {
+	mu     sync.Mutex
+	events []Event
+	next   int
+	full   bool
+}
+
+// newJournal returns a journal that keeps the last size events.
+func newJournal(size int) *journal {
+	return &journal{events: make([]Event, size)}
+}
+
+// add records e, overwriting the oldest event once the journal is full.
+func (j *journal) add(e Event) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.events[j.next] = e
+	j.next = (j.next + 1) % len(j.events)
+	if j.next == 0 {
+		j.full = true
+	}
+}
+
+// query returns the recorded events matching q, oldest first.
+func (j *journal) query(q HistoryQuery) []Event {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	ordered := j.events[:j.next]
+	if j.full {
+		ordered = append(j.events[j.next:len(j.events):len(j.events)], j.events[:j.next]...)
+	}
+
+	filter := EventFilter{Types: q.Types, Child: q.Child}
+	var events []Event
+	for _, e := range ordered {
+		if !filter.matches(e) {
+			continue
+		}
+		if !q.Since.IsZero() && e.Time.Before(q.Since) {
+			continue
+		}
+		if !q.Until.IsZero() && e.Time.After(q.Until) {
+			continue
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+// History returns the journaled events matching q, oldest first, including the
+// stack traces of panics and hung children. It returns nil unless the supervisor
+// was created with WithJournal. Only this supervisor's own events are journaled;
+// nested supervisors keep their own journals.
+//
+// This operation is safe to call from any goroutine, even after the supervisor stopped.
+//
+// Example:
+//
+//	crashes := sup.History(goverseer.HistoryQuery{
+//	    Types: []goverseer.EventType{goverseer.ChildPanicked},
+//	    Since: time.Now().Add(-time.Hour),
+//	})
+func (s *Supervisor) History(q HistoryQuery) []Event {
+	if s.journal == nil {
+		return nil
+	}
+	return s.journal.query(q)
+}
@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// contentType is the Prometheus text exposition format.
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// ServeHTTP writes the current metrics in the Prometheus text exposition format.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentType)
+	c.WriteTo(w)
+}
+
+// WriteTo writes the current metrics to w in the Prometheus text exposition format.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+
+	writeChildCounter(cw, "goverseer_child_starts_total", "Number of times a child was started.", c.starts)
+	writeChildCounter(cw, "goverseer_child_restarts_total", "Number of times a child was restarted by its strategy.", c.restarts)
+	writeChildCounter(cw, "goverseer_child_panics_total", "Number of times a child panicked.", c.panics)
+	writeChildCounter(cw, "goverseer_child_intensity_failures_total", "Number of times a child exceeded its own restart intensity.", c.childIntensity)
+
+	writeHeader(cw, "goverseer_supervisor_intensity_failures_total", "Number of times a supervisor exceeded its restart intensity.", "counter")
+	for _, sup := range slices.Sorted(maps.Keys(c.supervisorIntensity)) {
+		fmt.Fprintf(cw, "goverseer_supervisor_intensity_failures_total{supervisor=%s} %d\n", quote(sup), c.supervisorIntensity[sup])
+	}
+
+	writeGauge(cw, "goverseer_children_running", "Number of children currently running.", c.supervisors, c.running)
+	writeGauge(cw, "goverseer_children_backing_off", "Number of children waiting out a backoff delay before restarting.", c.supervisors, c.backingOff)
+
+	writeHistogram(cw, "goverseer_child_uptime_seconds", "How long children ran before exiting.", c.uptime)
+	writeHistogram(cw, "goverseer_restart_delay_seconds", "Backoff delay scheduled before restarting a failed child.", c.delay)
+
+	if err := cw.w.Flush(); err != nil {
+		return cw.n, err
+	}
+	return cw.n, cw.err
+}
+
+// writeHeader writes the HELP and TYPE lines of a metric.
+func writeHeader(w io.Writer, name, help, typ string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// writeChildCounter writes a counter labeled by supervisor and child. Values
+// counted per supervisor (WithPerSupervisorCounters) have no child label.
+func writeChildCounter(w io.Writer, name, help string, values map[childKey]uint64) {
+	writeHeader(w, name, help, "counter")
+	for _, key := range sortedKeys(values) {
+		if key.child == "" {
+			fmt.Fprintf(w, "%s{supervisor=%s} %d\n", name, quote(key.supervisor), values[key])
+			continue
+		}
+		fmt.Fprintf(w, "%s{supervisor=%s,child=%s} %d\n", name, quote(key.supervisor), quote(key.child), values[key])
+	}
+}
+
+// writeGauge writes, for each supervisor, how many of its children are set in states.
+func writeGauge(w io.Writer, name, help string, supervisors map[string]bool, states map[childKey]bool) {
+	counts := make(map[string]int)
+	for key, set := range states {
+		if set {
+			counts[key.supervisor]++
+		}
+	}
+
+	writeHeader(w, name, help, "gauge")
+	for _, sup := range slices.Sorted(maps.Keys(supervisors)) {
+		fmt.Fprintf(w, "%s{supervisor=%s} %d\n", name, quote(sup), counts[sup])
+	}
+}
+
+// writeHistogram writes a histogram per supervisor.
+func writeHistogram(w io.Writer, name, help string, hs map[string]*histogram) {
+	writeHeader(w, name, help, "histogram")
+	for _, sup := range slices.Sorted(maps.Keys(hs)) {
+		h := hs[sup]
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(w, "%s_bucket{supervisor=%s,le=%s} %d\n", name, quote(sup), quote(formatFloat(bound)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket{supervisor=%s,le=\"+Inf\"} %d\n", name, quote(sup), h.count)
+		fmt.Fprintf(w, "%s_sum{supervisor=%s} %s\n", name, quote(sup), formatFloat(h.sum))
+		fmt.Fprintf(w, "%s_count{supervisor=%s} %d\n", name, quote(sup), h.count)
+	}
+}
+
+// sortedKeys returns the keys of m ordered by supervisor, then child.
+func sortedKeys[V any](m map[childKey]V) []childKey {
+	return slices.SortedFunc(maps.Keys(m), func(a, b childKey) int {
+		if c := strings.Compare(a.supervisor, b.supervisor); c != 0 {
+			return c
+		}
+		return strings.Compare(a.child, b.child)
+	})
+}
+
+// labelEscaper escapes label values as the exposition format requires.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quote returns v as a quoted label value.
+func quote(v string) string {
+	return `"` + labelEscaper.Replace(v) + `"`
+}
+
+// formatFloat formats v the way Prometheus expects.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// countingWriter counts the bytes written and remembers the first error.
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
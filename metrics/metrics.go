@@ -0,0 +1,221 @@
+// Package metrics collects goverseer events into Prometheus metrics and serves
+// them in the Prometheus text exposition format, without any client library.
+//
+// Basic usage:
+//
+//	collector := metrics.New()
+//	stop := collector.Watch(sup)
+//	defer stop()
+//	http.Handle("/metrics", collector)
+//
+// Metrics are labeled with supervisor, the supervisor's Path (see goverseer.Event).
+// Gauges and histograms are per supervisor. Counters are also labeled with child,
+// which gives every child its own series: for a SimpleOneForOne pool, whose
+// instances get fresh names, that is one series per instance ever started. The
+// series of a child are dropped once it is removed (goverseer.ChildRemoved), but
+// a busy pool can still serve many of them; use WithPerSupervisorCounters to count
+// per supervisor only.
+package metrics
+
+import (
+	"sync"
+
+	"github.com/Gappylul/goverseer"
+)
+
+// Default histogram buckets, in seconds.
+var (
+	UptimeBuckets = []float64{1, 5, 15, 60, 300, 900, 3600, 6 * 3600, 24 * 3600}
+	DelayBuckets  = []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60}
+)
+
+// childKey identifies a child across the supervision tree.
+type childKey struct {
+	supervisor string
+	child      string
+}
+
+// Collector turns goverseer events into metrics. It is an http.Handler serving
+// them. Feed it events with Watch, or register Observe as an event handler.
+// All methods are safe for concurrent use.
+type Collector struct {
+	mu sync.Mutex
+
+	perSupervisor bool
+	// supervisors lists every supervisor seen, so gauges report zero rather than
+	// disappearing once a supervisor has no children.
+	supervisors map[string]bool
+
+	starts              map[childKey]uint64
+	restarts            map[childKey]uint64
+	panics              map[childKey]uint64
+	childIntensity      map[childKey]uint64
+	supervisorIntensity map[string]uint64
+
+	running    map[childKey]bool
+	backingOff map[childKey]bool
+
+	uptime map[string]*histogram
+	delay  map[string]*histogram
+}
+
+// Option configures a Collector.
+type Option func(*Collector)
+
+// WithPerSupervisorCounters counts starts, restarts, panics and child intensity
+// failures per supervisor, without the child label. Use it for supervisors with
+// many short-lived children, such as SimpleOneForOne pools.
+//
+// Example:
+//
+//	collector := metrics.New(metrics.WithPerSupervisorCounters())
+func WithPerSupervisorCounters() Option {
+	return func(c *Collector) {
+		c.perSupervisor = true
+	}
+}
+
+// New returns an empty Collector.
+func New(opts ...Option) *Collector {
+	c := &Collector{
+		supervisors:         make(map[string]bool),
+		starts:              make(map[childKey]uint64),
+		restarts:            make(map[childKey]uint64),
+		panics:              make(map[childKey]uint64),
+		childIntensity:      make(map[childKey]uint64),
+		supervisorIntensity: make(map[string]uint64),
+		running:             make(map[childKey]bool),
+		backingOff:          make(map[childKey]bool),
+		uptime:              make(map[string]*histogram),
+		delay:               make(map[string]*histogram),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Watch subscribes to the events of sup and of every supervisor nested under it,
+// until the returned function is called.
+//
+// Subscriptions drop events when they fall behind (see goverseer.Supervisor.Subscribe);
+// register Observe with goverseer.WithEventHandler instead if no event may be missed.
+func (c *Collector) Watch(sup *goverseer.Supervisor) (stop func()) {
+	events, cancel := sup.Subscribe(goverseer.EventFilter{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for e := range events {
+			c.Observe(e)
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// Observe records one event. Its signature matches goverseer.EventHandler.
+func (c *Collector) Observe(e goverseer.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.supervisors[e.Path] = true
+	key := childKey{supervisor: e.Path, child: e.ChildName}
+	counted := key
+	if c.perSupervisor {
+		counted.child = ""
+	}
+
+	switch e.Type {
+	case goverseer.ChildStarted:
+		c.starts[counted]++
+		c.running[key] = true
+		c.backingOff[key] = false
+
+	case goverseer.ChildRestarted:
+		c.restarts[counted]++
+
+	case goverseer.BackoffScheduled:
+		// Only the failed child backs off; siblings restarted with it have no delay
+		// of their own.
+		c.backingOff[key] = true
+		c.histogram(c.delay, e.Path, DelayBuckets).observe(e.Delay.Seconds())
+
+	case goverseer.ChildPanicked:
+		c.panics[counted]++
+		c.exited(key, e)
+
+	case goverseer.ChildExited, goverseer.ChildShutdownTimeout:
+		c.exited(key, e)
+
+	case goverseer.ChildStopped:
+		c.exited(key, e)
+		c.backingOff[key] = false
+
+	case goverseer.ChildFailedIntensity:
+		c.childIntensity[counted]++
+		c.backingOff[key] = false
+
+	case goverseer.SupervisorFailedIntensity:
+		c.supervisorIntensity[e.Path]++
+
+	case goverseer.SupervisorStopping:
+		// Pending restarts are canceled by the shutdown.
+		for k := range c.backingOff {
+			if k.supervisor == e.Path {
+				c.backingOff[k] = false
+			}
+		}
+
+	case goverseer.ChildRemoved:
+		delete(c.running, key)
+		delete(c.backingOff, key)
+		if !c.perSupervisor {
+			delete(c.starts, key)
+			delete(c.restarts, key)
+			delete(c.panics, key)
+			delete(c.childIntensity, key)
+		}
+	}
+}
+
+// exited records that a child is no longer running. c.mu must be held.
+func (c *Collector) exited(key childKey, e goverseer.Event) {
+	if !c.running[key] {
+		return
+	}
+	c.running[key] = false
+	c.histogram(c.uptime, e.Path, UptimeBuckets).observe(e.Uptime.Seconds())
+}
+
+// histogram returns the histogram for supervisor in hs, creating it if needed.
+// c.mu must be held.
+func (c *Collector) histogram(hs map[string]*histogram, supervisor string, buckets []float64) *histogram {
+	h, ok := hs[supervisor]
+	if !ok {
+		h = &histogram{buckets: buckets, counts: make([]uint64, len(buckets))}
+		hs[supervisor] = h
+	}
+	return h
+}
+
+// histogram is a Prometheus histogram with fixed upper bounds.
+type histogram struct {
+	buckets []float64
+	counts  []uint64 // non-cumulative count per bucket
+	sum     float64
+	count   uint64
+}
+
+func (h *histogram) observe(v float64) {
+	for i, bound := range h.buckets {
+		if v <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += v
+	h.count++
+}
@@ -0,0 +1,252 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Gappylul/goverseer"
+)
+
+// TestCollector tests that a crashing child is reflected in the exposed metrics
+func TestCollector(t *testing.T) {
+	collector := New()
+
+	var runs atomic.Int32
+	worker := func(ctx context.Context) error {
+		if runs.Add(1) == 1 {
+			panic("boom")
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	sup := goverseer.New(
+		goverseer.OneForOne,
+		goverseer.WithName("app"),
+		goverseer.WithBackoff(goverseer.ConstantBackoff(20*time.Millisecond)),
+		goverseer.WithEventHandler(collector.Observe),
+		goverseer.WithChildren(goverseer.ChildSpec{Name: "worker", Start: worker, Restart: goverseer.Permanent}),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	body := scrape(t, collector)
+	for _, line := range []string{
+		"# TYPE goverseer_child_starts_total counter",
+		`goverseer_child_starts_total{supervisor="app",child="worker"} 2`,
+		`goverseer_child_restarts_total{supervisor="app",child="worker"} 1`,
+		`goverseer_child_panics_total{supervisor="app",child="worker"} 1`,
+		`goverseer_children_running{supervisor="app"} 1`,
+		`goverseer_children_backing_off{supervisor="app"} 0`,
+		"# TYPE goverseer_restart_delay_seconds histogram",
+		`goverseer_restart_delay_seconds_bucket{supervisor="app",le="0.01"} 0`,
+		`goverseer_restart_delay_seconds_bucket{supervisor="app",le="0.05"} 1`,
+		`goverseer_restart_delay_seconds_bucket{supervisor="app",le="+Inf"} 1`,
+		`goverseer_restart_delay_seconds_sum{supervisor="app"} 0.02`,
+		`goverseer_restart_delay_seconds_count{supervisor="app"} 1`,
+		`goverseer_child_uptime_seconds_count{supervisor="app"} 1`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("expected %q in:\n%s", line, body)
+		}
+	}
+
+	sup.Stop()
+
+	body = scrape(t, collector)
+	if !strings.Contains(body, `goverseer_children_running{supervisor="app"} 0`+"\n") {
+		t.Errorf("expected no running children after Stop, got:\n%s", body)
+	}
+	if !strings.Contains(body, `goverseer_child_uptime_seconds_count{supervisor="app"} 2`+"\n") {
+		t.Errorf("expected both runs in the uptime histogram, got:\n%s", body)
+	}
+}
+
+// TestCollectorClearsBackoff tests that children removed or stopped while backing
+// off are no longer counted as backing off, and that removed children are pruned
+func TestCollectorClearsBackoff(t *testing.T) {
+	collector := New()
+
+	crash := func(ctx context.Context) error { return errors.New("crash") }
+	block := func(ctx context.Context) error { <-ctx.Done(); return nil }
+
+	sup := goverseer.New(
+		goverseer.OneForOne,
+		goverseer.WithName("app"),
+		goverseer.WithBackoff(goverseer.ConstantBackoff(time.Minute)),
+		goverseer.WithEventHandler(collector.Observe),
+		goverseer.WithChildren(
+			goverseer.ChildSpec{Name: "steady", Start: block, Restart: goverseer.Permanent},
+			goverseer.ChildSpec{Name: "removed", Start: crash, Restart: goverseer.Permanent},
+			goverseer.ChildSpec{Name: "stopped", Start: crash, Restart: goverseer.Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if body := scrape(t, collector); !strings.Contains(body, `goverseer_children_backing_off{supervisor="app"} 2`+"\n") {
+		t.Fatalf("expected 2 children backing off, got:\n%s", body)
+	}
+
+	if err := sup.RemoveChild("removed"); err != nil {
+		t.Fatalf("failed to remove child: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	body := scrape(t, collector)
+	if !strings.Contains(body, `goverseer_children_backing_off{supervisor="app"} 1`+"\n") {
+		t.Errorf("expected 1 child backing off after the removal, got:\n%s", body)
+	}
+	if strings.Contains(body, `child="removed"`) {
+		t.Errorf("expected the removed child's series to be dropped, got:\n%s", body)
+	}
+
+	sup.Stop()
+
+	body = scrape(t, collector)
+	for _, line := range []string{
+		`goverseer_children_backing_off{supervisor="app"} 0`,
+		`goverseer_children_running{supervisor="app"} 0`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("expected %q after Stop, got:\n%s", line, body)
+		}
+	}
+}
+
+// TestRestartDelayGroup tests that a group restart records only the failed child's delay
+func TestRestartDelayGroup(t *testing.T) {
+	collector := New()
+
+	block := func(ctx context.Context) error { <-ctx.Done(); return nil }
+	var runs atomic.Int32
+	crashOnce := func(ctx context.Context) error {
+		if runs.Add(1) == 1 {
+			time.Sleep(10 * time.Millisecond)
+			return errors.New("crash")
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	sup := goverseer.New(
+		goverseer.OneForAll,
+		goverseer.WithName("app"),
+		goverseer.WithBackoff(goverseer.ConstantBackoff(20*time.Millisecond)),
+		goverseer.WithEventHandler(collector.Observe),
+		goverseer.WithChildren(
+			goverseer.ChildSpec{Name: "a", Start: block, Restart: goverseer.Permanent},
+			goverseer.ChildSpec{Name: "b", Start: block, Restart: goverseer.Permanent},
+			goverseer.ChildSpec{Name: "c", Start: crashOnce, Restart: goverseer.Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	sup.Stop()
+
+	body := scrape(t, collector)
+	for _, line := range []string{
+		`goverseer_child_restarts_total{supervisor="app",child="a"} 1`,
+		`goverseer_child_restarts_total{supervisor="app",child="c"} 1`,
+		`goverseer_restart_delay_seconds_count{supervisor="app"} 1`,
+		`goverseer_restart_delay_seconds_sum{supervisor="app"} 0.02`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("expected %q in:\n%s", line, body)
+		}
+	}
+}
+
+// TestPerSupervisorCounters tests counting without the child label
+func TestPerSupervisorCounters(t *testing.T) {
+	collector := New(WithPerSupervisorCounters())
+
+	for _, name := range []string{"worker-1", "worker-2"} {
+		collector.Observe(goverseer.Event{Type: goverseer.ChildStarted, Path: "pool", ChildName: name})
+		collector.Observe(goverseer.Event{Type: goverseer.ChildExited, Path: "pool", ChildName: name, Reason: goverseer.ExitNormal})
+		collector.Observe(goverseer.Event{Type: goverseer.ChildRemoved, Path: "pool", ChildName: name})
+	}
+
+	body := scrape(t, collector)
+	if !strings.Contains(body, `goverseer_child_starts_total{supervisor="pool"} 2`+"\n") {
+		t.Errorf("expected starts counted per supervisor, got:\n%s", body)
+	}
+	if strings.Contains(body, "child=") {
+		t.Errorf("expected no child labels, got:\n%s", body)
+	}
+	if !strings.Contains(body, `goverseer_children_running{supervisor="pool"} 0`+"\n") {
+		t.Errorf("expected no running children, got:\n%s", body)
+	}
+}
+
+// TestWatch tests collecting events of nested supervisors through a subscription
+func TestWatch(t *testing.T) {
+	collector := New()
+
+	inner := goverseer.New(
+		goverseer.OneForOne,
+		goverseer.WithName("workers"),
+		goverseer.WithChildren(goverseer.ChildSpec{
+			Name:    `job "1"`,
+			Start:   func(ctx context.Context) error { <-ctx.Done(); return nil },
+			Restart: goverseer.Permanent,
+		}),
+	)
+	sup := goverseer.New(
+		goverseer.OneForOne,
+		goverseer.WithName("app"),
+		goverseer.WithChildren(goverseer.ChildSpec{Name: "workers", Supervisor: inner, Restart: goverseer.Permanent}),
+	)
+
+	stop := collector.Watch(sup)
+	defer stop()
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	defer sup.Stop()
+	time.Sleep(50 * time.Millisecond)
+
+	body := scrape(t, collector)
+	for _, line := range []string{
+		`goverseer_child_starts_total{supervisor="app",child="workers"} 1`,
+		`goverseer_child_starts_total{supervisor="app/workers",child="job \"1\""} 1`,
+		`goverseer_children_running{supervisor="app/workers"} 1`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("expected %q in:\n%s", line, body)
+		}
+	}
+}
+
+// scrape serves the collector's metrics over HTTP and returns the body.
+func scrape(t *testing.T, collector *Collector) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	collector.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != contentType {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+	body, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	return string(body)
+}
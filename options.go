@@ -22,6 +22,22 @@ func WithName(name string) Option {
 	}
 }
 
+// WithStrategy replaces how the supervisor picks the children to restart when one
+// fails. The Strategy passed to New still decides everything else, such as
+// SimpleOneForOne templates and parallel shutdown.
+//
+// Example:
+//
+//	sup := goverseer.New(
+//	    goverseer.OneForOne,
+//	    goverseer.WithStrategy(goverseer.StrategyFunc(restartWithDependents)),
+//	)
+func WithStrategy(strategy RestartStrategy) Option {
+	return func(s *Supervisor) {
+		s.restartStrategy = strategy
+	}
+}
+
 // WithIntensity sets restart intensity limits to prevent restart loops.
 // If more than maxRestarts occur within the time window, the supervisor
 // stops permanently and returns ErrIntensityExceeded.
@@ -57,9 +73,28 @@ func WithBackoff(policy BackoffPolicy) Option {
 	}
 }
 
+// WithStableAfter sets how long a restarted child must keep running before it is
+// considered healthy again. At that point its restart count is reset, so the
+// backoff policy starts over from its initial delay, and a ChildStable event is
+// emitted. Zero (the default) never resets the count. Individual children can
+// override it with ChildSpec.StableAfter.
+//
+// Example:
+//
+//	sup := goverseer.New(
+//	    goverseer.OneForOne,
+//	    goverseer.WithBackoff(goverseer.ExponentialBackoff(100*time.Millisecond, time.Minute)),
+//	    goverseer.WithStableAfter(10*time.Minute),
+//	)
+func WithStableAfter(d time.Duration) Option {
+	return func(s *Supervisor) {
+		s.stableAfter = d
+	}
+}
+
 // WithEventHandler adds an event handler to receive supervisor events.
 // Multiple handlers can be registered by calling this option multiple times.
-// Handlers should return quickly to avoid blocking the supervisor.
+// Handlers are called asynchronously; see EventHandler.
 //
 // Example:
 //
@@ -75,9 +110,70 @@ func WithEventHandler(handler EventHandler) Option {
 	}
 }
 
-// WithShutdownTimeout sets the maximum time to wait for children to stop gracefully.
-// After this timeout, the supervisor will exit even if children are still running.
-// The default is 30 seconds. If timeout is <= 0, the default is used.
+// WithEventBuffer sets the size of each event handler's queue and what happens when
+// a handler falls so far behind that it fills up. The default is a queue of 100
+// events with OverflowBlock. Dropped events are counted in EventStats.
+//
+// Example:
+//
+//	// Never let a slow log sink hold up restarts
+//	sup := goverseer.New(
+//	    goverseer.OneForOne,
+//	    goverseer.WithEventHandler(shipToLogService),
+//	    goverseer.WithEventBuffer(1000, goverseer.OverflowDropOldest),
+//	)
+func WithEventBuffer(size int, policy OverflowPolicy) Option {
+	return func(s *Supervisor) {
+		if size <= 0 {
+			size = defaultEventBuffer
+		}
+		s.eventBuffer = size
+		s.eventPolicy = policy
+	}
+}
+
+// WithJournal keeps the last size events in memory, so that they can be looked up
+// with History after the fact, e.g. to show recent crash reports in admin tooling.
+// By default no events are kept.
+//
+// Example:
+//
+//	sup := goverseer.New(
+//	    goverseer.OneForOne,
+//	    goverseer.WithJournal(1000),
+//	)
+func WithJournal(size int) Option {
+	return func(s *Supervisor) {
+		if size > 0 {
+			s.journal = newJournal(size)
+		}
+	}
+}
+
+// WithChildContext lets fn add values to the context of every child, each time it
+// is started. fn must return a context derived from ctx. It is how integrations
+// such as slogevents hand each child its own tools; multiple functions are applied
+// in order.
+//
+// Example:
+//
+//	sup := goverseer.New(
+//	    goverseer.OneForOne,
+//	    goverseer.WithChildContext(func(ctx context.Context, child goverseer.ChildInfo) context.Context {
+//	        return context.WithValue(ctx, metricsKey{}, registry.Scope(child.Name))
+//	    }),
+//	)
+func WithChildContext(fn func(ctx context.Context, child ChildInfo) context.Context) Option {
+	return func(s *Supervisor) {
+		s.childContexts = append(s.childContexts, fn)
+	}
+}
+
+// WithShutdownTimeout sets how long to wait for each child to stop gracefully.
+// Children are stopped one at a time, so without WithParallelShutdown stopping the
+// supervisor can take up to this timeout for every child. A child still running
+// after its timeout is left behind. The default is 30 seconds. If timeout is <= 0, the default is used.
+// Individual children can override it with ChildSpec.Shutdown.
 //
 // Example:
 //
@@ -94,6 +190,65 @@ func WithShutdownTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithParallelShutdown makes the supervisor cancel all children at once when shutting
+// down or restarting a group, instead of stopping them one at a time in reverse start order.
+// Each child still gets its own shutdown timeout, measured from the moment it was canceled.
+//
+// Example:
+//
+//	sup := goverseer.New(
+//	    goverseer.OneForOne,
+//	    goverseer.WithParallelShutdown(),
+//	)
+func WithParallelShutdown() Option {
+	return func(s *Supervisor) {
+		s.parallelShutdown = true
+	}
+}
+
+// WithAutoShutdown makes the supervisor shut itself down when its significant
+// children (ChildSpec.Significant) exit without being restarted, for example when
+// the main worker of a job completes while helpers are still running. Children
+// removed with RemoveChild don't count. The shutdown is a normal exit: Wait returns
+// nil, and a parent supervisor doesn't restart a Transient nested supervisor.
+//
+// Example:
+//
+//	job := goverseer.New(
+//	    goverseer.OneForAll,
+//	    goverseer.WithAutoShutdown(goverseer.AutoShutdownAnySignificant),
+//	    goverseer.WithChildren(
+//	        goverseer.ChildSpec{Name: "import", Start: runImport, Restart: goverseer.Transient, Significant: true},
+//	        goverseer.ChildSpec{Name: "progress", Start: reportProgress, Restart: goverseer.Permanent},
+//	    ),
+//	)
+func WithAutoShutdown(mode AutoShutdown) Option {
+	return func(s *Supervisor) {
+		s.autoShutdown = mode
+	}
+}
+
+// WithClassifier sets how the supervisor handles children that exit with an error,
+// for those whose ChildSpec.Classify doesn't decide. Returning DecisionDefault
+// lets the child's RestartType decide.
+//
+// Example:
+//
+//	sup := goverseer.New(
+//	    goverseer.OneForOne,
+//	    goverseer.WithClassifier(func(err error) goverseer.Decision {
+//	        if errors.Is(err, errBadConfig) {
+//	            return goverseer.DecisionStop
+//	        }
+//	        return goverseer.DecisionDefault
+//	    }),
+//	)
+func WithClassifier(classify func(err error) Decision) Option {
+	return func(s *Supervisor) {
+		s.classifier = classify
+	}
+}
+
 // WithChildren adds initial children to the supervisor.
 // Children are not started automatically; call Start() to begin supervision.
 //
@@ -108,13 +263,33 @@ func WithShutdownTimeout(timeout time.Duration) Option {
 //	)
 func WithChildren(specs ...ChildSpec) Option {
 	return func(s *Supervisor) {
-		for _, spec := range specs {
-			ch := &child{
-				spec: spec,
-			}
-			s.children = append(s.children, ch)
-			s.childMap[spec.Name] = ch
+		s.specs = append(s.specs, specs...)
+	}
+}
+
+// WithTemplate sets the child spec that a SimpleOneForOne supervisor starts
+// instances of with StartChild. Each instance gets a generated name made of the
+// template's Name (default "child") and a sequence number. The template should
+// use Start rather than Supervisor, since a *Supervisor can only run once at a time.
+//
+// Example:
+//
+//	pool := goverseer.New(
+//	    goverseer.SimpleOneForOne,
+//	    goverseer.WithTemplate(goverseer.ChildSpec{
+//	        Name:    "worker",
+//	        Start:   worker,
+//	        Restart: goverseer.Transient,
+//	    }),
+//	)
+//	pool.Start()
+//	id, _ := pool.StartChild(job) // "worker-1"
+func WithTemplate(spec ChildSpec) Option {
+	return func(s *Supervisor) {
+		if spec.Name == "" {
+			spec.Name = "child"
 		}
+		s.template = &spec
 	}
 }
 
@@ -130,7 +305,6 @@ func WithChildren(specs ...ChildSpec) Option {
 //	)
 func WithContext(ctx context.Context) Option {
 	return func(s *Supervisor) {
-		s.cancel() // Cancel the default context
-		s.ctx, s.cancel = context.WithCancel(ctx)
+		s.parent = ctx
 	}
 }
@@ -0,0 +1,39 @@
+package goverseer
+
+import (
+	"context"
+	"fmt"
+)
+
+// StartChild starts a new instance of the supervisor's template and returns its
+// generated name, which can be passed to RemoveChild and RestartChild.
+// args is made available to the instance through ChildArgs.
+//
+// Returns ErrNoTemplate unless the supervisor uses SimpleOneForOne with WithTemplate.
+// Instances started before Start are started by Start.
+//
+// This operation is safe to call from any goroutine.
+//
+// Example:
+//
+//	id, err := pool.StartChild(job)
+func (s *Supervisor) StartChild(args any) (string, error) {
+	return s.StartChildContext(context.Background(), args)
+}
+
+// StartChildContext is like StartChild but gives up when ctx is done, returning ctx's error.
+// It returns ErrSupervisorStopped right away if the supervisor is stopped or stopping.
+func (s *Supervisor) StartChildContext(ctx context.Context, args any) (string, error) {
+	if s.strategy != SimpleOneForOne || s.template == nil {
+		return "", ErrNoTemplate
+	}
+
+	spec := *s.template
+	spec.Name = fmt.Sprintf("%s-%d", s.template.Name, s.nextID.Add(1))
+	spec.args = args
+
+	if err := s.AddChildContext(ctx, spec); err != nil {
+		return "", err
+	}
+	return spec.Name, nil
+}
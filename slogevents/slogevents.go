@@ -0,0 +1,183 @@
+// Package slogevents logs goverseer events as structured log/slog records, and
+// gives every supervised child a logger tagged with its name and attempt.
+//
+// Basic usage:
+//
+//	logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
+//	sup := goverseer.New(
+//	    goverseer.OneForOne,
+//	    goverseer.WithEventHandler(slogevents.Handler(logger)),
+//	    slogevents.WithChildLogger(logger),
+//	)
+//
+//	func worker(ctx context.Context) error {
+//	    log := slogevents.FromContext(ctx)
+//	    log.Info("processing") // child=worker attempt=1 msg=processing
+//	    ...
+//	}
+package slogevents
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Gappylul/goverseer"
+)
+
+// Attribute keys used in records. They are part of the package's API and won't change.
+const (
+	KeyEvent      = "event"
+	KeySupervisor = "supervisor"
+	KeyPath       = "path"
+	KeyChild      = "child"
+	KeyReason     = "reason"
+	KeyRestarts   = "restarts"
+	KeyAttempt    = "attempt"
+	KeyUptime     = "uptime"
+	KeyDelay      = "delay"
+	KeyStrategy   = "strategy"
+	KeyError      = "error"
+	KeyStack      = "stack"
+)
+
+// Handler returns an event handler that logs every event to logger, at the level
+// given by Level and with the attributes given by Attrs. Records carry the time
+// the event happened rather than the time it was logged.
+func Handler(logger *slog.Logger) goverseer.EventHandler {
+	return func(e goverseer.Event) {
+		ctx := context.Background()
+		level := Level(e)
+		if !logger.Enabled(ctx, level) {
+			return
+		}
+
+		r := slog.NewRecord(e.Time, level, Message(e), 0)
+		r.AddAttrs(Attrs(e)...)
+		_ = logger.Handler().Handle(ctx, r)
+	}
+}
+
+// Level returns the level an event is logged at: Error for panics, hung children
+// and exceeded intensity limits, Warn for failures and restarts, and Info for the
+// rest of the lifecycle, such as starts and stops.
+func Level(e goverseer.Event) slog.Level {
+	switch e.Type {
+	case goverseer.ChildPanicked, goverseer.ChildHung, goverseer.HandlerPanicked,
+		goverseer.SupervisorFailedIntensity, goverseer.ChildFailedIntensity:
+		return slog.LevelError
+	case goverseer.ChildRestarted, goverseer.BackoffScheduled, goverseer.ChildShutdownTimeout,
+		goverseer.ChildHealthCheckFailed:
+		return slog.LevelWarn
+	case goverseer.ChildExited:
+		if e.Err != nil {
+			return slog.LevelWarn
+		}
+		return slog.LevelInfo
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// Message returns the message an event is logged with.
+func Message(e goverseer.Event) string {
+	switch e.Type {
+	case goverseer.ChildStarted:
+		return "child started"
+	case goverseer.ChildExited:
+		return "child exited"
+	case goverseer.ChildRestarted:
+		return "child restarted"
+	case goverseer.SupervisorStopping:
+		return "supervisor stopping"
+	case goverseer.SupervisorFailedIntensity:
+		return "supervisor restart intensity exceeded"
+	case goverseer.ChildPanicked:
+		return "child panicked"
+	case goverseer.ChildShutdownTimeout:
+		return "child shutdown timed out"
+	case goverseer.ChildFailedIntensity:
+		return "child restart intensity exceeded"
+	case goverseer.ChildStable:
+		return "child stable"
+	case goverseer.ChildHealthCheckFailed:
+		return "child health check failed"
+	case goverseer.ChildHealthCheckRecovered:
+		return "child health check recovered"
+	case goverseer.ChildHung:
+		return "child hung"
+	case goverseer.BackoffScheduled:
+		return "child restart scheduled"
+	case goverseer.ChildStopped:
+		return "child stopped"
+	case goverseer.HandlerPanicked:
+		return "event handler panicked"
+	case goverseer.ChildRemoved:
+		return "child removed"
+	default:
+		return e.Type.String()
+	}
+}
+
+// Attrs returns the attributes an event is logged with. Attributes that don't
+// apply to the event, like a delay on a start, are left out.
+func Attrs(e goverseer.Event) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String(KeyEvent, e.Type.String()),
+		slog.String(KeySupervisor, e.Supervisor),
+		slog.String(KeyPath, e.Path),
+	}
+	if e.ChildName != "" {
+		attrs = append(attrs,
+			slog.String(KeyChild, e.ChildName),
+			slog.Int(KeyRestarts, e.Restarts),
+			slog.Int(KeyAttempt, e.Attempt),
+		)
+	}
+	if e.Reason != goverseer.ExitNone {
+		attrs = append(attrs,
+			slog.String(KeyReason, e.Reason.String()),
+			slog.Duration(KeyUptime, e.Uptime),
+		)
+	}
+	if e.Delay > 0 {
+		attrs = append(attrs, slog.Duration(KeyDelay, e.Delay))
+	}
+	if e.Strategy != "" {
+		attrs = append(attrs, slog.String(KeyStrategy, e.Strategy))
+	}
+	if e.Err != nil {
+		attrs = append(attrs, slog.String(KeyError, e.Err.Error()))
+	}
+	if e.StackTrace != "" {
+		attrs = append(attrs, slog.String(KeyStack, e.StackTrace))
+	}
+	return attrs
+}
+
+// loggerKey is the context key under which a child's logger is stored.
+type loggerKey struct{}
+
+// WithChildLogger returns a supervisor option that gives every child a logger
+// derived from logger and tagged with the child's name and attempt. Children
+// get it with FromContext.
+func WithChildLogger(logger *slog.Logger) goverseer.Option {
+	return goverseer.WithChildContext(func(ctx context.Context, child goverseer.ChildInfo) context.Context {
+		return NewContext(ctx, logger.With(
+			slog.String(KeyChild, child.Name),
+			slog.Int(KeyAttempt, child.Attempt),
+		))
+	})
+}
+
+// NewContext returns a copy of ctx carrying logger.
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or slog.Default() if there is none.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,121 @@
+package slogevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Gappylul/goverseer"
+)
+
+// syncBuffer is a bytes.Buffer safe for the concurrent writes of handlers and children.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// records decodes the JSON log lines written so far.
+func (b *syncBuffer) records(t *testing.T) []map[string]any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var records []map[string]any
+	for line := range strings.SplitSeq(strings.TrimSpace(b.buf.String()), "\n") {
+		var r map[string]any
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+// TestHandlerAndChildLogger tests event records and child-scoped loggers
+func TestHandlerAndChildLogger(t *testing.T) {
+	var out syncBuffer
+	logger := slog.New(slog.NewJSONHandler(&out, nil))
+
+	var runs atomic.Int32
+	worker := func(ctx context.Context) error {
+		FromContext(ctx).Info("working")
+		if runs.Add(1) == 1 {
+			panic("boom")
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	sup := goverseer.New(
+		goverseer.OneForOne,
+		goverseer.WithName("app"),
+		goverseer.WithBackoff(goverseer.ConstantBackoff(0)),
+		goverseer.WithEventHandler(Handler(logger)),
+		WithChildLogger(logger),
+		goverseer.WithChildren(goverseer.ChildSpec{Name: "worker", Start: worker, Restart: goverseer.Permanent}),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	sup.Stop()
+
+	levels := make(map[string]string)
+	var working []map[string]any
+	for _, r := range out.records(t) {
+		if r["msg"] == "working" {
+			working = append(working, r)
+			continue
+		}
+		levels[r[KeyEvent].(string)] = r["level"].(string)
+
+		if r[KeyEvent] == "ChildPanicked" {
+			if r[KeySupervisor] != "app" || r[KeyChild] != "worker" || r[KeyReason] != "panic" {
+				t.Fatalf("unexpected panic record: %v", r)
+			}
+			if !strings.Contains(r[KeyStack].(string), "panic") {
+				t.Fatalf("expected a stack in the panic record, got: %v", r[KeyStack])
+			}
+		}
+	}
+
+	expected := map[string]string{
+		"ChildStarted":   "INFO",
+		"ChildPanicked":  "ERROR",
+		"ChildRestarted": "WARN",
+		"ChildStopped":   "INFO",
+	}
+	for event, level := range expected {
+		if levels[event] != level {
+			t.Errorf("%s: expected level %s, got %q", event, level, levels[event])
+		}
+	}
+
+	if len(working) != 2 {
+		t.Fatalf("expected 2 records from the child, got %d", len(working))
+	}
+	for i, r := range working {
+		if r[KeyChild] != "worker" || r[KeyAttempt] != float64(i+1) {
+			t.Fatalf("expected the child logger to be tagged with worker and attempt %d, got %v", i+1, r)
+		}
+	}
+}
+
+// TestFromContextDefault tests that FromContext falls back to the default logger
+func TestFromContextDefault(t *testing.T) {
+	if FromContext(context.Background()) != slog.Default() {
+		t.Fatal("expected slog.Default() outside a supervised child")
+	}
+}
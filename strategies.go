@@ -22,10 +22,78 @@ const (
 	RestForOne
 
 	// SimpleOneForOne is for dynamic worker pools where children are added/removed at runtime.
-	// Behaves like OneForOne but optimized for many similar children.
+	// Behaves like OneForOne but optimized for many similar children: instances are
+	// started from a template (see WithTemplate and StartChild), looked up and removed
+	// in constant time, and stopped in parallel.
 	SimpleOneForOne
 )
 
+// RestartStrategy decides which children are stopped and restarted together when
+// one of them fails. The built-in Strategy values implement it; custom strategies
+// are plugged in with WithStrategy.
+type RestartStrategy interface {
+	// Select returns the names of the children to restart along with failed.
+	// children lists every child of the supervisor in start order, failed included.
+	// The failed child is restarted whether or not it is returned, and the
+	// selected children are always stopped in reverse and restarted in start order.
+	// Select runs on the supervisor loop and must not call back into the supervisor.
+	Select(failed ChildInfo, children []ChildInfo) []string
+}
+
+// StrategyFunc adapts an ordinary function to a RestartStrategy.
+//
+// Example:
+//
+//	// Restart the failed child plus the children that depend on it.
+//	dependents := map[string][]string{"db": {"cache", "api"}}
+//	sup := goverseer.New(
+//	    goverseer.OneForOne,
+//	    goverseer.WithStrategy(goverseer.StrategyFunc(
+//	        func(failed goverseer.ChildInfo, children []goverseer.ChildInfo) []string {
+//	            return append([]string{failed.Name}, dependents[failed.Name]...)
+//	        },
+//	    )),
+//	)
+type StrategyFunc func(failed ChildInfo, children []ChildInfo) []string
+
+// Select calls f(failed, children).
+func (f StrategyFunc) Select(failed ChildInfo, children []ChildInfo) []string {
+	return f(failed, children)
+}
+
+// Select implements RestartStrategy for the built-in strategies.
+func (s Strategy) Select(failed ChildInfo, children []ChildInfo) []string {
+	switch s {
+	case OneForAll:
+		// Every child.
+		names := make([]string, 0, len(children))
+		for _, c := range children {
+			names = append(names, c.Name)
+		}
+		return names
+	case RestForOne:
+		// The failed child and all children started after it.
+		var names []string
+		for _, c := range children {
+			if c.Name == failed.Name || len(names) > 0 {
+				names = append(names, c.Name)
+			}
+		}
+		return names
+	default:
+		// Only the failed child.
+		return []string{failed.Name}
+	}
+}
+
+// strategyName returns the name events report for a restart strategy.
+func strategyName(strategy RestartStrategy) string {
+	if stringer, ok := strategy.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprintf("%T", strategy)
+}
+
 // String returns the string representation of a Strategy.
 func (s Strategy) String() string {
 	switch s {
@@ -74,117 +142,67 @@ func (rt RestartType) String() string {
 }
 
 // executeStrategy executes the configured restart strategy after a child fails.
-func (s *Supervisor) executeStrategy(exit *childExit, childExits chan *childExit) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	switch s.strategy {
-	case OneForOne:
-		return s.restartOne(exit, childExits)
-	case OneForAll:
-		return s.restartAll(childExits)
-	case RestForOne:
-		return s.restartRestForOne(exit, childExits)
-	case SimpleOneForOne:
-		return s.restartOne(exit, childExits)
-	default:
-		return fmt.Errorf("unknown strategy: %d", s.strategy)
-	}
+// Children in the affected group that are still running are stopped first.
+func (s *Supervisor) executeStrategy(failed *child, childExits chan *childExit) error {
+	group := s.affectedChildren(failed)
+	s.suspendChildren(group)
+	return s.restartChildren(group, childExits)
 }
 
-// restartOne restarts only the failed child (OneForOne and SimpleOneForOne strategies).
-func (s *Supervisor) restartOne(exit *childExit, childExits chan *childExit) error {
-	newChild := newChild(exit.child.spec, s.ctx, childExits)
-	newChild.restartCount = exit.child.restartCount + 1
-
-	// Replace in map and slice
-	s.childMap[exit.child.spec.Name] = newChild
-	for i, ch := range s.children {
-		if ch.spec.Name == exit.child.spec.Name {
-			s.children[i] = newChild
-			break
-		}
+// affectedChildren returns the children the restart strategy restarts when
+// failed exits, plus the children that depend on it, in start order.
+func (s *Supervisor) affectedChildren(failed *child) []*child {
+	// Don't look at every child of a (possibly large) pool just to pick one.
+	if s.restartStrategy == SimpleOneForOne {
+		return []*child{failed}
 	}
 
-	s.emitEvent(Event{
-		Time:      time.Now(),
-		ChildName: newChild.spec.Name,
-		Type:      ChildRestarted,
-	})
-
-	return s.startChild(newChild)
-}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-// restartAll stops all children and restarts all (OneForAll strategy).
-func (s *Supervisor) restartAll(childExits chan *childExit) error {
-	// Stop all children
-	for _, ch := range s.children {
-		ch.stop()
-	}
-
-	// Create new children
-	newChildren := make([]*child, 0, len(s.children))
-	for _, ch := range s.children {
-		newChild := newChild(ch.spec, s.ctx, childExits)
-		newChild.restartCount = ch.restartCount + 1
-		newChildren = append(newChildren, newChild)
-		s.childMap[ch.spec.Name] = newChild
+	selected := map[string]bool{failed.spec.Name: true}
+	if s.restartStrategy != OneForOne {
+		now := time.Now()
+		infos := make([]ChildInfo, 0, len(s.children))
+		for _, ch := range s.children {
+			infos = append(infos, ch.info(now))
+		}
+		for _, name := range s.restartStrategy.Select(failed.info(now), infos) {
+			selected[name] = true
+		}
 	}
+	s.addDependents(selected)
 
-	s.children = newChildren
-
-	// Start all children in order
+	group := make([]*child, 0, len(selected))
 	for _, ch := range s.children {
-		s.emitEvent(Event{
-			Time:      time.Now(),
-			ChildName: ch.spec.Name,
-			Type:      ChildRestarted,
-		})
-		if err := s.startChild(ch); err != nil {
-			return err
+		if selected[ch.spec.Name] {
+			group = append(group, ch)
 		}
 	}
-
-	return nil
+	return group
 }
 
-// restartRestForOne restarts the failed child and all children started after it (RestForOne strategy).
-func (s *Supervisor) restartRestForOne(exit *childExit, childExits chan *childExit) error {
-	// Find the index of the failed child
-	failedIndex := -1
-	for i, ch := range s.children {
-		if ch.spec.Name == exit.child.spec.Name {
-			failedIndex = i
-			break
-		}
-	}
+// restartChildren replaces each child with a new instance and starts them in order.
+func (s *Supervisor) restartChildren(children []*child, childExits chan *childExit) error {
+	for _, oldChild := range children {
+		oldChild.cancelRestart()
 
-	if failedIndex == -1 {
-		return nil
-	}
-
-	// Stop children from failedIndex onwards
-	for i := failedIndex; i < len(s.children); i++ {
-		s.children[i].stop()
-	}
-
-	// Restart from failedIndex onwards
-	for i := failedIndex; i < len(s.children); i++ {
-		oldChild := s.children[i]
 		newChild := newChild(oldChild.spec, s.ctx, childExits)
 		newChild.restartCount = oldChild.restartCount + 1
-
-		s.children[i] = newChild
-		s.childMap[newChild.spec.Name] = newChild
-
-		s.emitEvent(Event{
-			Time:      time.Now(),
-			ChildName: newChild.spec.Name,
-			Type:      ChildRestarted,
+		newChild.attempt = oldChild.attempt + 1
+		newChild.lastErr = oldChild.lastErr
+		newChild.restartHistory = oldChild.restartHistory
+		s.replaceChild(oldChild, newChild)
+
+		s.emitChildEvent(newChild, Event{
+			Type:     ChildRestarted,
+			Strategy: strategyName(s.restartStrategy),
 		})
 
 		if err := s.startChild(newChild); err != nil {
-			return err
+			// A child that doesn't come up counts as failed, once the rest
+			// of the group has been started.
+			s.failLater(newChild, err, childExits)
 		}
 	}
 
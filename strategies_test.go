@@ -3,6 +3,8 @@ package goverseer
 import (
 	"context"
 	"errors"
+	"slices"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -159,6 +161,648 @@ func TestRestForOneStrategy(t *testing.T) {
 	sup.Stop()
 }
 
+// TestOneForAllStopsSiblingsDuringBackoff tests that siblings don't keep running while the failed child backs off
+func TestOneForAllStopsSiblingsDuringBackoff(t *testing.T) {
+	var worker1Count atomic.Int32
+	var worker2Running atomic.Bool
+
+	worker1 := func(ctx context.Context) error {
+		if worker1Count.Add(1) == 1 {
+			return errors.New("worker1 error")
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	worker2 := func(ctx context.Context) error {
+		worker2Running.Store(true)
+		<-ctx.Done()
+		worker2Running.Store(false)
+		return nil
+	}
+
+	sup := New(
+		OneForAll,
+		WithName("one-for-all-backoff-test"),
+		WithBackoff(ConstantBackoff(200*time.Millisecond)),
+		WithChildren(
+			ChildSpec{Name: "worker2", Start: worker2, Restart: Permanent},
+			ChildSpec{Name: "worker1", Start: worker1, Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	// worker1 has failed and is backing off
+	time.Sleep(100 * time.Millisecond)
+	if worker2Running.Load() {
+		t.Fatal("worker2 should be stopped while worker1 backs off")
+	}
+
+	// After the backoff both are running again
+	time.Sleep(250 * time.Millisecond)
+	if !worker2Running.Load() {
+		t.Fatal("worker2 should be restarted after the backoff")
+	}
+	if worker1Count.Load() != 2 {
+		t.Fatalf("worker1 should have run twice, count: %d", worker1Count.Load())
+	}
+
+	sup.Stop()
+}
+
+// childStates returns the state of each child by name.
+func childStates(t *testing.T, sup *Supervisor) map[string]ChildState {
+	t.Helper()
+
+	infos, err := sup.Children()
+	if err != nil {
+		t.Fatalf("failed to list children: %v", err)
+	}
+	states := make(map[string]ChildState, len(infos))
+	for _, info := range infos {
+		states[info.Name] = info.State
+	}
+	return states
+}
+
+// newBackingOffGroup starts a OneForAll supervisor with children a, b and c, and
+// waits until b has failed and is backing off with its siblings stopped.
+func newBackingOffGroup(t *testing.T) *Supervisor {
+	t.Helper()
+
+	block := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+	var bRuns atomic.Int32
+	b := func(ctx context.Context) error {
+		if bRuns.Add(1) == 1 {
+			time.Sleep(20 * time.Millisecond) // let c start first
+			return errors.New("b error")
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	sup := New(
+		OneForAll,
+		WithBackoff(ConstantBackoff(300*time.Millisecond)),
+		WithChildren(
+			ChildSpec{Name: "a", Start: block, Restart: Permanent},
+			ChildSpec{Name: "b", Start: b, Restart: Permanent},
+			ChildSpec{Name: "c", Start: block, Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(60 * time.Millisecond)
+
+	states := childStates(t, sup)
+	if states["a"] != StateRestarting || states["b"] != StateBackingOff || states["c"] != StateRestarting {
+		t.Fatalf("expected b backing off with a and c waiting, got %v", states)
+	}
+	return sup
+}
+
+// TestRemoveBackingOffChildRestartsGroup tests that removing a child that is backing
+// off restarts the siblings waiting for it
+func TestRemoveBackingOffChildRestartsGroup(t *testing.T) {
+	sup := newBackingOffGroup(t)
+	defer sup.Stop()
+
+	if err := sup.RemoveChild("b"); err != nil {
+		t.Fatalf("failed to remove child: %v", err)
+	}
+
+	states := childStates(t, sup)
+	if len(states) != 2 || states["a"] != StateRunning || states["c"] != StateRunning {
+		t.Fatalf("expected a and c running after removing b, got %v", states)
+	}
+}
+
+// TestRestartBackingOffChildRestartsGroup tests that restarting a child that is backing
+// off restarts the siblings waiting for it too
+func TestRestartBackingOffChildRestartsGroup(t *testing.T) {
+	sup := newBackingOffGroup(t)
+	defer sup.Stop()
+
+	if err := sup.RestartChild("b"); err != nil {
+		t.Fatalf("failed to restart child: %v", err)
+	}
+
+	states := childStates(t, sup)
+	for _, name := range []string{"a", "b", "c"} {
+		if states[name] != StateRunning {
+			t.Fatalf("expected all children running after restarting b, got %v", states)
+		}
+	}
+
+	// The canceled backoff must not restart the group again.
+	time.Sleep(350 * time.Millisecond)
+	infos, err := sup.Children()
+	if err != nil {
+		t.Fatalf("failed to list children: %v", err)
+	}
+	for _, info := range infos {
+		if info.State != StateRunning || info.Attempt != 2 {
+			t.Fatalf("expected each child running its second attempt, got %+v", info)
+		}
+	}
+}
+
+// TestOneForAllReportsSiblingExit tests that a sibling which failed on its own
+// before the group restart stopped it is reported with its own error
+func TestOneForAllReportsSiblingExit(t *testing.T) {
+	var mu sync.Mutex
+	var events []Event
+
+	fire := make(chan struct{})
+	failOnce := func(delay time.Duration, err error) func(ctx context.Context) error {
+		var runs atomic.Int32
+		return func(ctx context.Context) error {
+			if runs.Add(1) == 1 {
+				select {
+				case <-fire:
+					time.Sleep(delay)
+					return err
+				case <-ctx.Done():
+					return nil
+				}
+			}
+			<-ctx.Done()
+			return nil
+		}
+	}
+
+	sup := New(
+		OneForAll,
+		WithName("one-for-all-sibling-exit-test"),
+		WithBackoff(ConstantBackoff(0)),
+		WithEventHandler(func(e Event) {
+			mu.Lock()
+			events = append(events, e)
+			mu.Unlock()
+		}),
+		WithChildren(
+			ChildSpec{Name: "a", Start: failOnce(0, errors.New("a failed")), Restart: Permanent},
+			ChildSpec{Name: "b", Start: failOnce(10*time.Millisecond, errors.New("b failed: important")), Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	defer sup.Stop()
+
+	// Keep the loop busy while both children fail, so that b has returned by
+	// the time a's exit stops it.
+	slow := func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		Ready(ctx)
+		<-ctx.Done()
+		return nil
+	}
+	go sup.AddChild(ChildSpec{Name: "slow", Start: slow, Restart: Permanent, StartTimeout: time.Second})
+	time.Sleep(10 * time.Millisecond)
+	close(fire)
+	time.Sleep(150 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, e := range events {
+		if e.ChildName == "b" && e.Type == ChildExited && e.Reason == ExitError && e.Err != nil && e.Err.Error() == "b failed: important" {
+			return
+		}
+	}
+	t.Fatalf("expected b's own error to be reported, got %v", events)
+}
+
+// TestCustomStrategy tests restarting the failed child plus the named children that depend on it
+func TestCustomStrategy(t *testing.T) {
+	var restarts restartCounter
+	var dbRuns atomic.Int32
+
+	block := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+	db := func(ctx context.Context) error {
+		if dbRuns.Add(1) == 1 {
+			time.Sleep(20 * time.Millisecond)
+			return errors.New("connection lost")
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	dependents := map[string][]string{"db": {"api"}}
+	var seen []string
+	sup := New(
+		OneForOne,
+		WithName("custom-strategy-test"),
+		WithBackoff(ConstantBackoff(0)),
+		WithEventHandler(restarts.handle),
+		WithStrategy(StrategyFunc(func(failed ChildInfo, children []ChildInfo) []string {
+			for _, c := range children {
+				seen = append(seen, c.Name)
+			}
+			return dependents[failed.Name]
+		})),
+		WithChildren(
+			ChildSpec{Name: "db", Start: db, Restart: Permanent},
+			ChildSpec{Name: "metrics", Start: block, Restart: Permanent},
+			ChildSpec{Name: "api", Start: block, Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	sup.Stop()
+
+	if !slices.Equal(seen, []string{"db", "metrics", "api"}) {
+		t.Fatalf("strategy should see every child in start order, got %v", seen)
+	}
+	if restarts.get("db") != 1 || restarts.get("api") != 1 {
+		t.Fatalf("db and api should restart once, got %d and %d", restarts.get("db"), restarts.get("api"))
+	}
+	if restarts.get("metrics") != 0 {
+		t.Fatalf("metrics should not restart, got %d", restarts.get("metrics"))
+	}
+}
+
+// TestBuiltinStrategiesSelect tests the built-in strategies through the RestartStrategy interface
+func TestBuiltinStrategiesSelect(t *testing.T) {
+	children := []ChildInfo{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	failed := children[1]
+
+	tests := []struct {
+		strategy Strategy
+		expected []string
+	}{
+		{OneForOne, []string{"b"}},
+		{OneForAll, []string{"a", "b", "c"}},
+		{RestForOne, []string{"b", "c"}},
+		{SimpleOneForOne, []string{"b"}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.strategy.Select(failed, children); !slices.Equal(got, tt.expected) {
+			t.Errorf("%v: expected %v, got %v", tt.strategy, tt.expected, got)
+		}
+	}
+}
+
+// TestSimpleOneForOneTemplate tests starting, restarting and removing template instances
+func TestSimpleOneForOneTemplate(t *testing.T) {
+	const instances = 1000
+
+	var running atomic.Int32
+	var argSum atomic.Int64
+	var crashed atomic.Bool
+
+	worker := func(ctx context.Context) error {
+		n := ChildArgs(ctx).(int)
+		if n == 7 && crashed.CompareAndSwap(false, true) {
+			return errors.New("instance 7 error")
+		}
+		argSum.Add(int64(n))
+		running.Add(1)
+		defer running.Add(-1)
+		<-ctx.Done()
+		return nil
+	}
+
+	pool := New(
+		SimpleOneForOne,
+		WithName("pool"),
+		WithBackoff(ConstantBackoff(time.Millisecond)),
+		WithTemplate(ChildSpec{Name: "worker", Start: worker, Restart: Permanent}),
+	)
+
+	if err := pool.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	ids := make([]string, 0, instances)
+	seen := make(map[string]bool)
+	for i := 1; i <= instances; i++ {
+		id, err := pool.StartChild(i)
+		if err != nil {
+			t.Fatalf("failed to start instance %d: %v", i, err)
+		}
+		if seen[id] {
+			t.Fatalf("duplicate instance id %q", id)
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	if ids[0] != "worker-1" {
+		t.Fatalf("expected generated names based on the template, got %q", ids[0])
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Instance 7 crashed once and was restarted with the same args
+	want := int64(instances * (instances + 1) / 2)
+	if argSum.Load() != want || running.Load() != instances {
+		t.Fatalf("expected %d running instances with arg sum %d, got %d and %d",
+			instances, want, running.Load(), argSum.Load())
+	}
+
+	for _, id := range ids[:instances/2] {
+		if err := pool.RemoveChild(id); err != nil {
+			t.Fatalf("failed to remove %s: %v", id, err)
+		}
+	}
+
+	count, err := pool.Count()
+	if err != nil {
+		t.Fatalf("failed to count children: %v", err)
+	}
+	if count.Active != instances/2 {
+		t.Fatalf("expected %d active instances, got %d", instances/2, count.Active)
+	}
+
+	if err := pool.RestartChild(ids[instances-1]); err != nil {
+		t.Fatalf("failed to restart instance: %v", err)
+	}
+
+	if err := pool.Stop(); err != nil {
+		t.Fatalf("failed to stop supervisor: %v", err)
+	}
+	if running.Load() != 0 {
+		t.Fatalf("instances still running after stop: %d", running.Load())
+	}
+}
+
+// TestSimpleOneForOneKeepsRunningWhenEmpty tests that a template pool outlives its last instance
+func TestSimpleOneForOneKeepsRunningWhenEmpty(t *testing.T) {
+	pool := New(
+		SimpleOneForOne,
+		WithName("empty-pool"),
+		WithTemplate(ChildSpec{
+			Start:   func(ctx context.Context) error { return nil },
+			Restart: Temporary,
+		}),
+	)
+
+	if err := pool.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	id, err := pool.StartChild(nil)
+	if err != nil {
+		t.Fatalf("failed to start instance: %v", err)
+	}
+	if id != "child-1" {
+		t.Fatalf("expected default template name, got %q", id)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if _, err := pool.StartChild(nil); err != nil {
+		t.Fatalf("pool should still accept instances: %v", err)
+	}
+
+	pool.Stop()
+}
+
+// TestStartChildRequiresTemplate tests that StartChild needs a SimpleOneForOne template
+func TestStartChildRequiresTemplate(t *testing.T) {
+	spec := ChildSpec{Name: "worker", Start: func(ctx context.Context) error { return nil }}
+
+	for _, sup := range []*Supervisor{
+		New(SimpleOneForOne),
+		New(OneForOne, WithTemplate(spec)),
+	} {
+		if _, err := sup.StartChild(nil); !errors.Is(err, ErrNoTemplate) {
+			t.Fatalf("expected ErrNoTemplate, got: %v", err)
+		}
+	}
+}
+
+// restartCounter counts ChildRestarted events per child.
+type restartCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (rc *restartCounter) handle(e Event) {
+	if e.Type != ChildRestarted {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.counts == nil {
+		rc.counts = make(map[string]int)
+	}
+	rc.counts[e.ChildName]++
+}
+
+func (rc *restartCounter) get(name string) int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.counts[name]
+}
+
+// TestStaleExitsOneForOne tests that a manually restarted child's old run doesn't trigger a restart
+func TestStaleExitsOneForOne(t *testing.T) {
+	var runCount atomic.Int32
+	var restarts restartCounter
+
+	worker := func(ctx context.Context) error {
+		runCount.Add(1)
+		<-ctx.Done()
+		return errors.New("stopped") // Would look like a failure
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("stale-one-for-one-test"),
+		WithBackoff(ConstantBackoff(0)),
+		WithEventHandler(restarts.handle),
+		WithChildren(
+			ChildSpec{Name: "worker", Start: worker, Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	for i := 0; i < 5; i++ {
+		if err := sup.RestartChild("worker"); err != nil {
+			t.Fatalf("failed to restart child: %v", err)
+		}
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if runCount.Load() != 6 {
+		t.Fatalf("expected exactly 6 runs, got %d", runCount.Load())
+	}
+	if restarts.get("worker") != 0 {
+		t.Fatalf("stale exits caused %d strategy restarts", restarts.get("worker"))
+	}
+
+	sup.Stop()
+}
+
+// TestStaleExitsOneForAll tests that siblings stopped by OneForAll don't trigger extra restarts
+func TestStaleExitsOneForAll(t *testing.T) {
+	var worker1Count, worker2Count atomic.Int32
+	var restarts restartCounter
+
+	worker1 := func(ctx context.Context) error {
+		if worker1Count.Add(1) == 1 {
+			time.Sleep(20 * time.Millisecond) // Let worker2 start
+			return errors.New("worker1 error")
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	worker2 := func(ctx context.Context) error {
+		worker2Count.Add(1)
+		<-ctx.Done()
+		return errors.New("stopped")
+	}
+
+	sup := New(
+		OneForAll,
+		WithName("stale-one-for-all-test"),
+		WithBackoff(ConstantBackoff(0)),
+		WithEventHandler(restarts.handle),
+		WithChildren(
+			ChildSpec{Name: "worker1", Start: worker1, Restart: Permanent},
+			ChildSpec{Name: "worker2", Start: worker2, Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	if worker1Count.Load() != 2 || worker2Count.Load() != 2 {
+		t.Fatalf("expected each worker to run twice, got worker1=%d worker2=%d", worker1Count.Load(), worker2Count.Load())
+	}
+	if restarts.get("worker1") != 1 || restarts.get("worker2") != 1 {
+		t.Fatalf("expected one restart each, got worker1=%d worker2=%d", restarts.get("worker1"), restarts.get("worker2"))
+	}
+
+	sup.Stop()
+}
+
+// TestStaleExitsRestForOne tests that children stopped by RestForOne don't trigger extra restarts
+func TestStaleExitsRestForOne(t *testing.T) {
+	var worker1Count, worker2Count, worker3Count atomic.Int32
+	var restarts restartCounter
+
+	stoppable := func(counter *atomic.Int32) ChildFunc {
+		return func(ctx context.Context) error {
+			counter.Add(1)
+			<-ctx.Done()
+			return errors.New("stopped")
+		}
+	}
+
+	worker2 := func(ctx context.Context) error {
+		if worker2Count.Add(1) == 1 {
+			time.Sleep(20 * time.Millisecond) // Let worker3 start
+			return errors.New("worker2 error")
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	sup := New(
+		RestForOne,
+		WithName("stale-rest-for-one-test"),
+		WithBackoff(ConstantBackoff(0)),
+		WithEventHandler(restarts.handle),
+		WithChildren(
+			ChildSpec{Name: "worker1", Start: stoppable(&worker1Count), Restart: Permanent},
+			ChildSpec{Name: "worker2", Start: worker2, Restart: Permanent},
+			ChildSpec{Name: "worker3", Start: stoppable(&worker3Count), Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	if worker1Count.Load() != 1 || worker2Count.Load() != 2 || worker3Count.Load() != 2 {
+		t.Fatalf("unexpected runs: worker1=%d worker2=%d worker3=%d",
+			worker1Count.Load(), worker2Count.Load(), worker3Count.Load())
+	}
+	if restarts.get("worker1") != 0 || restarts.get("worker2") != 1 || restarts.get("worker3") != 1 {
+		t.Fatalf("unexpected restarts: worker1=%d worker2=%d worker3=%d",
+			restarts.get("worker1"), restarts.get("worker2"), restarts.get("worker3"))
+	}
+
+	sup.Stop()
+}
+
+// TestStaleExitsRemovedChild tests that removing a Permanent child doesn't bring it back
+func TestStaleExitsRemovedChild(t *testing.T) {
+	var runCount atomic.Int32
+
+	worker := func(ctx context.Context) error {
+		runCount.Add(1)
+		<-ctx.Done()
+		return errors.New("stopped")
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("stale-removed-test"),
+		WithBackoff(ConstantBackoff(0)),
+		WithChildren(
+			ChildSpec{Name: "worker", Start: worker, Restart: Permanent},
+			ChildSpec{Name: "other", Start: worker, Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if err := sup.RemoveChild("worker"); err != nil {
+		t.Fatalf("failed to remove child: %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if runCount.Load() != 2 {
+		t.Fatalf("expected 2 runs, got %d", runCount.Load())
+	}
+	infos, err := sup.Children()
+	if err != nil {
+		t.Fatalf("failed to list children: %v", err)
+	}
+	if len(infos) != 1 || infos[0].Name != "other" {
+		t.Fatalf("removed child came back: %+v", infos)
+	}
+
+	sup.Stop()
+}
+
 // ====================================================================
 // backoff_test.go - Backoff Policy Tests
 // ====================================================================
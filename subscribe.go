@@ -0,0 +1,102 @@
+package goverseer
+
+import (
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// EventFilter selects the events delivered to a subscription. Empty fields match
+// everything, so the zero EventFilter matches every event.
+type EventFilter struct {
+	// Types lists the event types to deliver.
+	Types []EventType
+	// Child is a path.Match pattern for the child name, e.g. "worker-*".
+	Child string
+	// Subtree is the Path of a supervisor (see Event.Path), e.g. "app/workers".
+	// Only events from that supervisor and the supervisors nested under it are delivered.
+	Subtree string
+}
+
+// matches reports whether e passes the filter.
+func (f EventFilter) matches(e Event) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, e.Type) {
+		return false
+	}
+	if f.Child != "" {
+		if ok, _ := path.Match(f.Child, e.ChildName); !ok {
+			return false
+		}
+	}
+	if f.Subtree != "" && e.Path != f.Subtree && !strings.HasPrefix(e.Path, f.Subtree+"/") {
+		return false
+	}
+	return true
+}
+
+// subscription is a channel of events registered with Subscribe.
+type subscription struct {
+	filter EventFilter
+	events chan Event
+}
+
+// Subscribe returns a channel receiving the events that match filter, from this
+// supervisor and every supervisor nested under it, until cancel is called.
+// It can be called at any time, before or after Start, and the subscription
+// outlives restarts of the supervisor. cancel closes the channel; it is safe to
+// call more than once.
+//
+// The channel is buffered (see WithEventBuffer for its size). Events that don't fit
+// are dropped rather than holding up the supervisor, so read it promptly.
+//
+// Example:
+//
+//	events, cancel := sup.Subscribe(goverseer.EventFilter{
+//	    Types: []goverseer.EventType{goverseer.ChildRestarted},
+//	    Child: "worker-3",
+//	})
+//	defer cancel()
+//	e := <-events // the next restart of worker-3
+func (s *Supervisor) Subscribe(filter EventFilter) (<-chan Event, func()) {
+	sub := &subscription{
+		filter: filter,
+		events: make(chan Event, s.eventBuffer),
+	}
+
+	s.subMu.Lock()
+	if s.subscriptions == nil {
+		s.subscriptions = make(map[*subscription]struct{})
+	}
+	s.subscriptions[sub] = struct{}{}
+	s.subMu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			s.subMu.Lock()
+			delete(s.subscriptions, sub)
+			close(sub.events)
+			s.subMu.Unlock()
+		})
+	}
+	return sub.events, cancel
+}
+
+// publish delivers e to the matching subscriptions of this supervisor and of the
+// supervisors it is nested under.
+func (s *Supervisor) publish(e Event) {
+	for sup := s; sup != nil; sup = sup.owner {
+		sup.subMu.RLock()
+		for sub := range sup.subscriptions {
+			if !sub.filter.matches(e) {
+				continue
+			}
+			select {
+			case sub.events <- e:
+			default:
+			}
+		}
+		sup.subMu.RUnlock()
+	}
+}
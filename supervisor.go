@@ -21,8 +21,11 @@ package goverseer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -34,13 +37,28 @@ import (
 // to ensure race-free state management.
 type Supervisor struct {
 	// Configuration
-	name            string
-	strategy        Strategy
-	maxRestarts     int
-	restartWindow   time.Duration
-	backoff         BackoffPolicy
-	shutdownTimeout time.Duration
-	eventHandlers   []EventHandler
+	name             string
+	path             string      // names of the supervisors from the root down to this one
+	owner            *Supervisor // the supervisor this one runs under, if nested
+	strategy         Strategy
+	restartStrategy  RestartStrategy
+	maxRestarts      int
+	restartWindow    time.Duration
+	backoff          BackoffPolicy
+	shutdownTimeout  time.Duration
+	parallelShutdown bool
+	stableAfter      time.Duration
+	autoShutdown     AutoShutdown
+	classifier       func(error) Decision
+	eventHandlers    []EventHandler
+	eventBuffer      int
+	eventPolicy      OverflowPolicy
+	journal          *journal
+	childContexts    []func(context.Context, ChildInfo) context.Context
+	parent           context.Context
+	specs            []ChildSpec
+	template         *ChildSpec
+	nextID           atomic.Uint64
 
 	// State (protected by mu or accessed via commands channel)
 	mu             sync.RWMutex
@@ -50,17 +68,23 @@ type Supervisor struct {
 	cancel         context.CancelFunc
 	done           chan struct{}
 	commands       chan command
+	deferred       chan func() error
 	restartHistory []time.Time
+	dispatchers    []*dispatcher
+	subMu          sync.RWMutex
+	subscriptions  map[*subscription]struct{}
+	running        bool
 	stopped        bool
 	finalErr       error
 }
 
 // command represents an internal command to the supervisor's actor loop.
 type command struct {
-	action   string     // "add", "remove", "restart"
-	spec     *ChildSpec // for "add"
-	name     string     // for "remove", "restart"
-	response chan error // synchronous response channel
+	action   string           // "add", "remove", "restart", "children"
+	spec     *ChildSpec       // for "add"
+	name     string           // for "remove", "restart"
+	response chan error       // synchronous response channel
+	infos    chan []ChildInfo // for "children"
 }
 
 // New creates a new Supervisor with the given strategy and options.
@@ -74,22 +98,20 @@ type command struct {
 //	    goverseer.WithIntensity(10, time.Minute),
 //	    goverseer.WithBackoff(goverseer.ExponentialBackoff(100*time.Millisecond, 5*time.Second)),
 //	)
-
 func New(strategy Strategy, opts ...Option) *Supervisor {
-	ctx, cancel := context.WithCancel(context.Background())
-
 	s := &Supervisor{
 		name:            "supervisor",
 		strategy:        strategy,
+		restartStrategy: strategy,
 		maxRestarts:     10,
 		restartWindow:   time.Minute,
 		backoff:         ExponentialBackoff(100*time.Millisecond, 5*time.Second),
 		shutdownTimeout: 30 * time.Second,
+		eventBuffer:     defaultEventBuffer,
+		parent:          context.Background(),
 		childMap:        make(map[string]*child),
-		ctx:             ctx,
-		cancel:          cancel,
 		done:            make(chan struct{}),
-		commands:        make(chan command, 10),
+		deferred:        make(chan func() error),
 		restartHistory:  make([]time.Time, 0),
 	}
 
@@ -97,59 +119,153 @@ func New(strategy Strategy, opts ...Option) *Supervisor {
 		opt(s)
 	}
 
-	go s.run()
-
 	return s
 }
 
-// Start starts the supervisor and all its children in order.
-// Children are started sequentially, and if any child fails to start,
-// Start returns an error immediately without starting remaining children.
+// Start starts the supervisor and all its children in order, each after the
+// children it depends on (see ChildSpec.DependsOn).
+// Children are started sequentially, waiting for those with a StartTimeout to
+// report Ready. If any child fails to start, the supervisor is stopped and Start
+// returns an error naming that child, without starting the remaining children.
 //
-// Returns ErrSupervisorStopped if the supervisor has already been stopped.
+// Returns ErrSupervisorStopped if the supervisor has already been stopped,
+// ErrSupervisorRunning if it is already running (including as a nested child), and
+// ErrMissingDependency or ErrDependencyCycle if its children's dependencies are invalid.
 func (s *Supervisor) Start() error {
 	s.mu.Lock()
 	if s.stopped {
 		s.mu.Unlock()
 		return ErrSupervisorStopped
 	}
+	if s.running {
+		s.mu.Unlock()
+		return ErrSupervisorRunning
+	}
+	specs, err := sortSpecs(s.specs)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+
+	s.launch(s.parent)
+	s.mu.Unlock()
 
-	// Get snapshot of children specs that need initialization
-	childrenToInit := make([]ChildSpec, 0, len(s.children))
-	for _, ch := range s.children {
-		childrenToInit = append(childrenToInit, ch.spec)
+	if err := s.startChildren(specs); err != nil {
+		s.Stop()
+		return err
 	}
+	return nil
+}
 
-	// Clear the placeholder children
-	s.children = nil
-	for k := range s.childMap {
-		delete(s.childMap, k)
+// serve runs the supervisor as the child of another supervisor and blocks until it stops.
+// The parent's child context becomes the parent of the supervisor's own context, so
+// stopping the parent shuts down the whole subtree. A supervisor that already stopped
+// (for example after exceeding its intensity) is reset first, which lets the parent
+// restart it with its original children.
+func (s *Supervisor) serve(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return ErrSupervisorRunning
+	}
+	specs, err := sortSpecs(s.specs)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	if s.stopped {
+		s.reset()
 	}
+
+	s.launch(ctx)
 	s.mu.Unlock()
 
-	// Add each child properly through the command system
-	for _, spec := range childrenToInit {
+	if err := s.startChildren(specs); err != nil {
+		s.Stop()
+		return err
+	}
+	Ready(ctx)
+
+	if err := s.Wait(); err != nil {
+		return fmt.Errorf("supervisor %s: %w", s.name, err)
+	}
+	return nil
+}
+
+// launch starts a new run of the actor loop under parent. s.mu must be held.
+// Each run gets its own commands channel, so requests left unanswered by a
+// previous run can't leak into this one.
+func (s *Supervisor) launch(parent context.Context) {
+	s.path = s.name
+	s.owner, _ = parent.Value(supervisorKey{}).(*Supervisor)
+	if s.owner != nil {
+		s.path = s.owner.path + "/" + s.name
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	s.ctx, s.cancel = context.WithValue(ctx, supervisorKey{}, s), cancel
+	s.startDispatchers()
+	s.commands = make(chan command, 10)
+	s.running = true
+	go s.run()
+}
+
+// reset clears the state left behind by a finished run. s.mu must be held.
+func (s *Supervisor) reset() {
+	s.children = nil
+	s.childMap = make(map[string]*child)
+	s.restartHistory = make([]time.Time, 0)
+	s.done = make(chan struct{})
+	s.stopped = false
+	s.finalErr = nil
+}
+
+// startChildren adds each spec through the command system, in order.
+func (s *Supervisor) startChildren(specs []ChildSpec) error {
+	for _, spec := range specs {
 		if err := s.AddChild(spec); err != nil {
 			return fmt.Errorf("failed to start child %s: %w", spec.Name, err)
 		}
 	}
-
 	return nil
 }
 
 // AddChild dynamically adds a child to the supervisor at runtime.
 // The child is started immediately. If a child with the same name already exists,
-// returns ErrChildAlreadyExists.
+// returns ErrChildAlreadyExists. Children added before Start are started by Start.
+// If the spec sets a StartTimeout, AddChild waits for the child to report Ready;
+// a child that doesn't is stopped and not added.
 //
 // This operation is safe to call from any goroutine.
 func (s *Supervisor) AddChild(spec ChildSpec) error {
-	response := make(chan error, 1)
-	s.commands <- command{
-		action:   "add",
-		spec:     &spec,
-		response: response,
+	return s.AddChildContext(context.Background(), spec)
+}
+
+// AddChildContext is like AddChild but gives up when ctx is done, returning ctx's error.
+// It returns ErrSupervisorStopped right away if the supervisor is stopped or stopping.
+// If ctx is done after the request reached the supervisor, the child may still be added.
+func (s *Supervisor) AddChildContext(ctx context.Context, spec ChildSpec) error {
+	if err := spec.validate(); err != nil {
+		return err
 	}
-	return <-response
+
+	s.mu.Lock()
+	if !s.running && !s.stopped {
+		defer s.mu.Unlock()
+		for _, existing := range s.specs {
+			if existing.Name == spec.Name {
+				return ErrChildAlreadyExists
+			}
+		}
+		s.specs = append(s.specs, spec)
+		return nil
+	}
+	s.mu.Unlock()
+
+	return s.call(ctx, command{
+		action: "add",
+		spec:   &spec,
+	})
 }
 
 // RemoveChild removes a child from the supervisor and stops it gracefully.
@@ -157,13 +273,30 @@ func (s *Supervisor) AddChild(spec ChildSpec) error {
 //
 // This operation is safe to call from any goroutine.
 func (s *Supervisor) RemoveChild(name string) error {
-	response := make(chan error, 1)
-	s.commands <- command{
-		action:   "remove",
-		name:     name,
-		response: response,
+	return s.RemoveChildContext(context.Background(), name)
+}
+
+// RemoveChildContext is like RemoveChild but gives up when ctx is done, returning ctx's error.
+// It returns ErrSupervisorStopped right away if the supervisor is stopped or stopping.
+// If ctx is done after the request reached the supervisor, the child may still be removed.
+func (s *Supervisor) RemoveChildContext(ctx context.Context, name string) error {
+	s.mu.Lock()
+	if !s.running && !s.stopped {
+		defer s.mu.Unlock()
+		for i, spec := range s.specs {
+			if spec.Name == name {
+				s.specs = slices.Delete(s.specs, i, i+1)
+				return nil
+			}
+		}
+		return ErrChildNotFound
 	}
-	return <-response
+	s.mu.Unlock()
+
+	return s.call(ctx, command{
+		action: "remove",
+		name:   name,
+	})
 }
 
 // RestartChild manually restarts a specific child by name.
@@ -172,23 +305,91 @@ func (s *Supervisor) RemoveChild(name string) error {
 //
 // This operation is safe to call from any goroutine.
 func (s *Supervisor) RestartChild(name string) error {
-	response := make(chan error, 1)
-	s.commands <- command{
-		action:   "restart",
-		name:     name,
-		response: response,
+	return s.RestartChildContext(context.Background(), name)
+}
+
+// RestartChildContext is like RestartChild but gives up when ctx is done, returning ctx's error.
+// It returns ErrSupervisorStopped right away if the supervisor is stopped or stopping.
+// If ctx is done after the request reached the supervisor, the child may still be restarted.
+func (s *Supervisor) RestartChildContext(ctx context.Context, name string) error {
+	s.mu.Lock()
+	if !s.running && !s.stopped {
+		// Not started yet: the child will run for the first time on Start.
+		defer s.mu.Unlock()
+		for _, spec := range s.specs {
+			if spec.Name == name {
+				return nil
+			}
+		}
+		return ErrChildNotFound
+	}
+	s.mu.Unlock()
+
+	return s.call(ctx, command{
+		action: "restart",
+		name:   name,
+	})
+}
+
+// call sends cmd to the supervisor loop and waits for its response.
+// It never blocks on a loop that has exited: once the supervisor is stopping it
+// returns ErrSupervisorStopped, and it returns ctx's error if ctx is done first.
+func (s *Supervisor) call(ctx context.Context, cmd command) error {
+	s.mu.RLock()
+	running, loopCtx, commands := s.running, s.ctx, s.commands
+	s.mu.RUnlock()
+
+	if !running || loopCtx.Err() != nil {
+		return ErrSupervisorStopped
+	}
+
+	cmd.response = make(chan error, 1)
+	select {
+	case commands <- cmd:
+	case <-loopCtx.Done():
+		return ErrSupervisorStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-cmd.response:
+		return err
+	case <-loopCtx.Done():
+		// The loop may have handled the command just before stopping.
+		select {
+		case err := <-cmd.response:
+			return err
+		default:
+			return ErrSupervisorStopped
+		}
+	case <-ctx.Done():
+		return ctx.Err()
 	}
-	return <-response
 }
 
 // Stop gracefully stops the supervisor and all its children.
-// It cancels the supervisor's context, waits for all children to exit
-// (up to the configured shutdown timeout), and returns any final error.
+// It cancels the supervisor's context, stops the children (in reverse start order
+// unless WithParallelShutdown is set), waits up to the shutdown timeout for each
+// one, and returns any final error.
+// Children that miss the timeout are reported in a *ShutdownTimeoutError.
 //
-// This method blocks until shutdown is complete.
+// This method blocks until shutdown is complete. A supervisor running as a
+// nested child is restarted by its parent according to its RestartType.
 func (s *Supervisor) Stop() error {
-	s.cancel()
-	<-s.done
+	s.mu.Lock()
+	if !s.running && !s.stopped {
+		// Never started, so there is no loop to wait for.
+		s.stopped = true
+		close(s.done)
+	}
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	<-done
 
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -204,7 +405,11 @@ func (s *Supervisor) Stop() error {
 //	    log.Fatal(err)
 //	}
 func (s *Supervisor) Wait() error {
-	<-s.done
+	s.mu.RLock()
+	done := s.done
+	s.mu.RUnlock()
+
+	<-done
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	return s.finalErr
@@ -213,11 +418,27 @@ func (s *Supervisor) Wait() error {
 // run is the main supervisor event loop implementing the actor model.
 // All state mutations happen in this single goroutine, ensuring race-free operation.
 func (s *Supervisor) run() {
-	defer close(s.done)
-	defer s.shutdownChildren()
+	defer func() {
+		shutdownErr := s.shutdownChildren()
+		// Let handlers catch up, so that Stop and Wait return after the last event.
+		s.stopDispatchers()
+
+		s.mu.Lock()
+		if s.finalErr == nil {
+			s.finalErr = shutdownErr
+		} else if shutdownErr != nil {
+			s.finalErr = errors.Join(s.finalErr, shutdownErr)
+		}
+		s.running = false
+		s.stopped = true
+		done := s.done
+		s.mu.Unlock()
+		close(done)
+	}()
 
 	// Use a fixed buffer size instead of reading s.children length
 	childExits := make(chan *childExit, 100)
+	commands := s.commands
 
 	for {
 		select {
@@ -228,22 +449,46 @@ func (s *Supervisor) run() {
 			})
 			return
 
-		case cmd := <-s.commands:
+		case cmd := <-commands:
 			s.handleCommand(cmd, childExits)
 
 		case exit := <-childExits:
 			if err := s.handleChildExit(exit, childExits); err != nil {
-				s.mu.Lock()
-				s.finalErr = err
-				s.stopped = true
-				s.mu.Unlock()
-				s.cancel()
+				s.fail(err)
+				return
+			}
+
+		case fn := <-s.deferred:
+			if err := fn(); err != nil {
+				s.fail(err)
 				return
 			}
 		}
 	}
 }
 
+// fail records err as the reason the supervisor stopped and cancels its context.
+func (s *Supervisor) fail(err error) {
+	s.mu.Lock()
+	s.finalErr = err
+	s.stopped = true
+	s.mu.Unlock()
+	s.cancel()
+}
+
+// after runs fn on the supervisor loop once delay has elapsed, unless the
+// supervisor stops first. It lets timers feed back into the actor loop instead
+// of blocking it.
+func (s *Supervisor) after(delay time.Duration, fn func() error) *time.Timer {
+	ctx := s.ctx
+	return time.AfterFunc(delay, func() {
+		select {
+		case s.deferred <- fn:
+		case <-ctx.Done():
+		}
+	})
+}
+
 // handleCommand processes commands from the commands channel.
 func (s *Supervisor) handleCommand(cmd command, childExits chan *childExit) {
 	var err error
@@ -252,9 +497,11 @@ func (s *Supervisor) handleCommand(cmd command, childExits chan *childExit) {
 	case "add":
 		err = s.doAddChild(cmd.spec, childExits)
 	case "remove":
-		err = s.doRemoveChild(cmd.name)
+		err = s.doRemoveChild(cmd.name, childExits)
 	case "restart":
 		err = s.doRestartChild(cmd.name, childExits)
+	case "children":
+		cmd.infos <- s.childInfos()
 	}
 
 	cmd.response <- err
@@ -263,187 +510,605 @@ func (s *Supervisor) handleCommand(cmd command, childExits chan *childExit) {
 // doAddChild implements the add child operation.
 func (s *Supervisor) doAddChild(spec *ChildSpec, childExits chan *childExit) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	if s.stopped {
+		s.mu.Unlock()
 		return ErrSupervisorStopped
 	}
 
 	if _, exists := s.childMap[spec.Name]; exists {
+		s.mu.Unlock()
 		return ErrChildAlreadyExists
 	}
+	if err := s.checkDependencies(spec); err != nil {
+		s.mu.Unlock()
+		return err
+	}
 
 	ch := newChild(*spec, s.ctx, childExits)
+	ch.index = len(s.children)
 	s.children = append(s.children, ch)
 	s.childMap[spec.Name] = ch
+	s.mu.Unlock()
 
-	return s.startChild(ch)
+	if err := s.startChild(ch); err != nil {
+		s.mu.Lock()
+		s.removeChild(ch)
+		s.mu.Unlock()
+		s.stopChildren([]*child{ch}, err)
+		s.emitChildEvent(ch, Event{Type: ChildRemoved, Err: err})
+		return err
+	}
+	return nil
 }
 
 // doRemoveChild implements the remove child operation.
-func (s *Supervisor) doRemoveChild(name string) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
+// Siblings stopped to be restarted with the child are restarted right away,
+// since the pending restart they were waiting for is canceled.
+func (s *Supervisor) doRemoveChild(name string, childExits chan *childExit) error {
+	s.mu.RLock()
 	ch, exists := s.childMap[name]
+	s.mu.RUnlock()
+
 	if !exists {
 		return ErrChildNotFound
 	}
 
-	ch.stop()
+	suspended := s.suspendedGroup(ch)
 
-	// Remove from slice
-	for i, c := range s.children {
-		if c == ch {
-			s.children = append(s.children[:i], s.children[i+1:]...)
-			break
-		}
-	}
+	s.mu.Lock()
+	s.removeChild(ch)
+	s.mu.Unlock()
 
-	delete(s.childMap, name)
-	return nil
+	ch.cancelRestart()
+	s.stopChildren([]*child{ch}, ErrChildRemoved)
+	s.emitChildEvent(ch, Event{Type: ChildRemoved})
+
+	return s.restartChildren(suspended, childExits)
 }
 
 // doRestartChild implements the restart child operation.
 func (s *Supervisor) doRestartChild(name string, childExits chan *childExit) error {
-	s.mu.Lock()
+	s.mu.RLock()
 	ch, exists := s.childMap[name]
-	s.mu.Unlock()
+	s.mu.RUnlock()
 
 	if !exists {
 		return ErrChildNotFound
 	}
 
-	ch.stop()
+	// Siblings waiting for ch's pending restart are restarted with it, in start order.
+	suspended := s.suspendedGroup(ch)
+	i := 0
+	for i < len(suspended) && suspended[i].index < ch.index {
+		i++
+	}
+
+	ch.cancelRestart()
+	s.stopChildren([]*child{ch}, ErrManualRestart)
+	s.restartChildren(suspended[:i], childExits)
+
+	newChild := newChild(ch.spec, s.ctx, childExits)
+	newChild.attempt = ch.attempt + 1
+	newChild.lastErr = ch.lastErr
+	newChild.restartHistory = ch.restartHistory
+	s.replaceChild(ch, newChild)
+
+	err := s.startChild(newChild)
+	if err != nil {
+		s.failLater(newChild, err, childExits)
+	}
+	s.restartChildren(suspended[i:], childExits)
+	return err
+}
+
+// suspendedGroup returns the siblings that were stopped to be restarted together
+// with failed once its backoff elapses, in start order. It returns nil unless
+// failed is backing off.
+func (s *Supervisor) suspendedGroup(failed *child) []*child {
+	if failed.state != StateBackingOff {
+		return nil
+	}
 
+	var suspended []*child
+	for _, ch := range s.affectedChildren(failed) {
+		if ch != failed && ch.state == StateRestarting {
+			suspended = append(suspended, ch)
+		}
+	}
+	return suspended
+}
+
+// replaceChild swaps old for its replacement in both the map and the ordered slice.
+func (s *Supervisor) replaceChild(old, replacement *child) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	ch = newChild(ch.spec, s.ctx, childExits)
-	s.childMap[name] = ch
+	s.childMap[old.spec.Name] = replacement
+	if old.index < len(s.children) && s.children[old.index] == old {
+		replacement.index = old.index
+		s.children[old.index] = replacement
+	}
+}
 
-	for i, c := range s.children {
-		if c.spec.Name == name {
-			s.children[i] = ch
-			break
-		}
+// removeChild drops ch from both the map and the ordered slice. s.mu must be held.
+// SimpleOneForOne children have no meaningful order, so the last child is moved
+// into the gap instead of shifting the rest of the slice.
+func (s *Supervisor) removeChild(ch *child) {
+	delete(s.childMap, ch.spec.Name)
+
+	i := ch.index
+	if i >= len(s.children) || s.children[i] != ch {
+		return
 	}
 
-	return s.startChild(ch)
+	if s.strategy == SimpleOneForOne {
+		last := len(s.children) - 1
+		s.children[i] = s.children[last]
+		s.children[i].index = i
+		s.children[last] = nil
+		s.children = s.children[:last]
+		return
+	}
+
+	s.children = slices.Delete(s.children, i, i+1)
+	for j := i; j < len(s.children); j++ {
+		s.children[j].index = j
+	}
 }
 
-// shutdownChildren gracefully shuts down all children with a timeout.
-func (s *Supervisor) shutdownChildren() {
+// shutdownChildren stops all children and waits for their goroutines to return.
+// Children still running after their shutdown timeout are reported in a
+// ShutdownTimeoutError and left behind.
+func (s *Supervisor) shutdownChildren() error {
 	s.mu.Lock()
-	children := make([]*child, len(s.children))
-	copy(children, s.children)
+	children := slices.Clone(s.children)
 	s.mu.Unlock()
 
-	// Stop all children by canceling their contexts
 	for _, ch := range children {
-		ch.stop()
+		ch.cancelRestart()
 	}
 
-	// Wait for all to stop with timeout
-	timeout := time.After(s.shutdownTimeout)
-	ticker := time.NewTicker(50 * time.Millisecond)
-	defer ticker.Stop()
+	if missed := s.stopChildren(children, ErrShutdown); len(missed) > 0 {
+		return &ShutdownTimeoutError{Children: missed}
+	}
+	return nil
+}
 
-	for {
-		allStopped := true
+// stopChildren stops the given children with cause and waits for each to return
+// within its own shutdown policy. By default children are stopped one at a time in reverse order,
+// so dependents exit before what they depend on; WithParallelShutdown (and the
+// SimpleOneForOne strategy, whose children are interchangeable) cancels them all
+// at once instead. It returns the names of the children that missed their deadline.
+func (s *Supervisor) stopChildren(children []*child, cause error) []string {
+	var missed []string
+	check := func(ch *child, since time.Time, wasRunning, returned bool) {
+		if !s.awaitStop(ch, since) {
+			missed = append(missed, ch.spec.Name)
+			s.emitChildEvent(ch, Event{
+				Type:   ChildShutdownTimeout,
+				Err:    ErrShutdownTimeout,
+				Reason: ExitTimeout,
+			})
+			return
+		}
+		// Children that were waiting to restart have already reported their exit.
+		if wasRunning {
+			s.emitStopped(ch, returned)
+		}
+	}
+	// stop reports whether ch was running, and whether it had already returned
+	// on its own, in which case its exit won't be processed.
+	stop := func(ch *child) (wasRunning, returned bool) {
+		wasRunning = ch.state == StateRunning
+		select {
+		case <-ch.returned:
+			// A child failed by failChild returned because it was stopped.
+			returned = !ch.isStopped()
+		default:
+		}
+		ch.stop(cause)
+		ch.state = StateStopped
+		return wasRunning, returned
+	}
 
-		s.mu.RLock()
-		for _, ch := range s.children {
-			if !ch.isStopped() {
-				allStopped = false
-				break
-			}
+	if s.parallelShutdown || s.strategy == SimpleOneForOne {
+		since := time.Now()
+		running := make([]bool, len(children))
+		returned := make([]bool, len(children))
+		for i, ch := range children {
+			running[i], returned[i] = stop(ch)
+		}
+		for i, ch := range children {
+			check(ch, since, running[i], returned[i])
 		}
-		s.mu.RUnlock()
+		return missed
+	}
 
-		if allStopped {
-			return
+	for i := len(children) - 1; i >= 0; i-- {
+		ch := children[i]
+		wasRunning, returned := stop(ch)
+		check(ch, time.Now(), wasRunning, returned)
+	}
+	return missed
+}
+
+// emitStopped reports the exit of a child the supervisor stopped. If the child
+// had already returned before it was stopped, its own exit is reported instead,
+// since the exit it sent is discarded as stale.
+func (s *Supervisor) emitStopped(ch *child, returned bool) {
+	e := Event{
+		Type:   ChildStopped,
+		Reason: ExitShutdown,
+	}
+	switch {
+	case returned:
+		e.Type = ChildExited
+		if ch.exit.panic {
+			e.Type = ChildPanicked
+		}
+		e.Reason = ch.exit.reason()
+		e.Err = ch.exit.err
+		e.StackTrace = ch.exit.stackTrace
+		if ch.exit.err != nil {
+			ch.lastErr = ch.exit.err
 		}
+	case ch.spec.Shutdown == ShutdownBrutalKill:
+		// The goroutine may still be running, so its outcome isn't known.
+		e.Reason = ExitKilled
+	case ch.exit.panic:
+		e.Type = ChildPanicked
+		e.Reason = ExitPanic
+		e.Err = ch.exit.err
+		e.StackTrace = ch.exit.stackTrace
+	}
+	s.emitChildEvent(ch, e)
+}
 
-		select {
-		case <-timeout:
-			// Force exit after timeout
-			return
-		case <-ticker.C:
-			continue
+// suspendChildren stops the running children in group and marks the whole group
+// as waiting to be restarted. Children already backing off keep that state.
+func (s *Supervisor) suspendChildren(group []*child) {
+	var running []*child
+	for _, ch := range group {
+		if ch.state == StateRunning {
+			running = append(running, ch)
 		}
 	}
+
+	s.stopChildren(running, ErrSiblingFailed)
+
+	for _, ch := range running {
+		ch.state = StateRestarting
+	}
+}
+
+// awaitStop waits for a stopped child to return, according to its shutdown policy
+// measured from since. It reports false if the child missed its deadline.
+func (s *Supervisor) awaitStop(ch *child, since time.Time) bool {
+	if ch.spec.Shutdown == ShutdownInfinity {
+		<-ch.done
+		return true
+	}
+	timeout, ok := s.shutdownTimeoutFor(ch)
+	if !ok {
+		return true
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), since.Add(timeout))
+	defer cancel()
+	return ch.wait(ctx)
+}
+
+// shutdownTimeoutFor returns how long ch may take to return once stopped: its own
+// Shutdown, or the supervisor's. It reports false if the child has no deadline,
+// because it is abandoned right away (ShutdownBrutalKill) or waited for as long
+// as it takes (ShutdownInfinity).
+func (s *Supervisor) shutdownTimeoutFor(ch *child) (time.Duration, bool) {
+	switch timeout := ch.spec.Shutdown; {
+	case timeout == ShutdownBrutalKill, timeout == ShutdownInfinity:
+		return 0, false
+	case timeout <= 0:
+		return s.shutdownTimeout, true
+	default:
+		return timeout, true
+	}
 }
 
 // startChild starts a single child and emits the appropriate event.
+// If the child has a StartTimeout, it returns once the child is ready, or with
+// the reason it isn't; the child is left running either way.
 func (s *Supervisor) startChild(ch *child) error {
-	s.emitEvent(Event{
-		Time:      time.Now(),
-		ChildName: ch.spec.Name,
-		Type:      ChildStarted,
-	})
+	ch.state = StateRunning
+	ch.startedAt = time.Now()
+	for _, decorate := range s.childContexts {
+		ch.ctx = decorate(ch.ctx, ch.info(ch.startedAt))
+	}
+	s.emitChildEvent(ch, Event{Type: ChildStarted})
 
 	ch.start()
+
+	if err := s.awaitReady(ch); err != nil {
+		return err
+	}
+
+	if ch.spec.HealthCheck != nil {
+		go s.monitorHealth(ch)
+	}
+	if ch.spec.WatchdogInterval > 0 {
+		go s.watchdog(ch)
+	}
+
+	// Forget past restarts once the child has stayed up long enough.
+	if stableAfter := s.stableAfterFor(ch); stableAfter > 0 && ch.restartCount > 0 {
+		ch.stableTimer = s.after(stableAfter, func() error {
+			s.markStable(ch)
+			return nil
+		})
+	}
 	return nil
 }
 
-// handleChildExit processes a child exit and decides whether to restart.
+// awaitReady waits for a child with a StartTimeout to call Ready.
+// It blocks the loop like OTP's synchronous init, but gives up if the supervisor stops.
+func (s *Supervisor) awaitReady(ch *child) error {
+	if ch.spec.StartTimeout <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(ch.spec.StartTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-ch.ready:
+		return nil
+	case <-ch.returned:
+		// Ready may have been called just before returning.
+		select {
+		case <-ch.ready:
+			return nil
+		default:
+		}
+		if ch.exit.err != nil {
+			return errors.Join(ErrExitedBeforeReady, ch.exit.err)
+		}
+		return ErrExitedBeforeReady
+	case <-timer.C:
+		return ErrStartTimeout
+	case <-s.ctx.Done():
+		return ErrSupervisorStopped
+	}
+}
+
+// failLater reports ch as failed with err from the loop, once the current
+// operation is done, so that the failure goes through the restart strategy.
+func (s *Supervisor) failLater(ch *child, err error, childExits chan *childExit) {
+	s.after(0, func() error {
+		return s.failChild(ch, err, childExits)
+	})
+}
+
+// failChild treats a running child as if it had returned err: it is stopped
+// with err as the cause, and the failure is handled like any other exit once the
+// child has returned or missed its shutdown deadline. The wait happens off the
+// loop, since a hung child never returns; the child stays tracked meanwhile, so
+// its replacement doesn't overlap it and Stop still waits for it.
+func (s *Supervisor) failChild(ch *child, err error, childExits chan *childExit) error {
+	if !s.isCurrent(ch) {
+		return nil
+	}
+
+	ch.stop(err)
+	since, ctx := time.Now(), s.ctx
+	go func() {
+		stopped := s.awaitStop(ch, since)
+		select {
+		case s.deferred <- func() error { return s.childFailed(ch, err, stopped, childExits) }:
+		case <-ctx.Done():
+		}
+	}()
+	return nil
+}
+
+// childFailed handles the exit of a child failed by failChild, once it has
+// returned (stopped) or missed its shutdown deadline.
+func (s *Supervisor) childFailed(ch *child, err error, stopped bool, childExits chan *childExit) error {
+	if !s.isTracked(ch) || ch.state != StateRunning {
+		// Removed, restarted, or stopped with its group in the meantime.
+		return nil
+	}
+
+	if !stopped {
+		s.emitChildEvent(ch, Event{
+			Type:   ChildShutdownTimeout,
+			Err:    ErrShutdownTimeout,
+			Reason: ExitTimeout,
+		})
+	}
+	return s.processExit(&childExit{child: ch, err: err}, childExits)
+}
+
+// stableAfterFor returns how long ch must run before its restart count resets:
+// its own StableAfter, or the supervisor's.
+func (s *Supervisor) stableAfterFor(ch *child) time.Duration {
+	if ch.spec.StableAfter != 0 {
+		return ch.spec.StableAfter
+	}
+	return s.stableAfter
+}
+
+// markStable resets the restart count, and with it the backoff, of a child that
+// has been running for its stable-after duration.
+func (s *Supervisor) markStable(ch *child) {
+	ch.stableTimer = nil
+	if !s.isCurrent(ch) || ch.state != StateRunning {
+		return
+	}
+
+	ch.restartCount = 0
+	s.emitChildEvent(ch, Event{Type: ChildStable})
+}
+
+// handleChildExit processes an exit reported by a child's goroutine.
 func (s *Supervisor) handleChildExit(exit *childExit, childExits chan *childExit) error {
+	if !s.isCurrent(exit.child) {
+		// A run the supervisor stopped on purpose (restart, removal, or a
+		// sibling's group restart). Its replacement is already tracked.
+		return nil
+	}
+	return s.processExit(exit, childExits)
+}
+
+// processExit handles the exit of the current run of a child.
+func (s *Supervisor) processExit(exit *childExit, childExits chan *childExit) error {
+	exit.child.cancelStable()
+
 	eventType := ChildExited
 	if exit.panic {
 		eventType = ChildPanicked
 	}
 
-	s.emitEvent(Event{
-		Time:       time.Now(),
-		ChildName:  exit.child.spec.Name,
+	s.emitChildEvent(exit.child, Event{
 		Type:       eventType,
 		Err:        exit.err,
 		StackTrace: exit.stackTrace,
+		Reason:     exit.reason(),
 	})
 
-	// Check if we should restart based on restart type.
+	if exit.err != nil {
+		exit.child.lastErr = exit.err
+	}
+
+	// Check if we should restart based on the error, then on restart type.
 	shouldRestart := s.shouldRestart(exit)
+	switch s.classify(exit.child, exit.err) {
+	case DecisionRestart:
+		shouldRestart = true
+	case DecisionStop:
+		shouldRestart = false
+	case DecisionEscalate:
+		return fmt.Errorf("child %s: %w: %w", exit.child.spec.Name, ErrEscalated, exit.err)
+	}
 
 	if !shouldRestart {
-		// Child won't restart - remove it from tracking.
-		s.mu.Lock()
-		delete(s.childMap, exit.child.spec.Name)
-		for i, c := range s.children {
-			if c.spec.Name == exit.child.spec.Name {
-				s.children = append(s.children[:i], s.children[i+1:]...)
-				break
+		s.dropChild(exit.child)
+		return nil
+	}
+
+	// Check the child's own restart intensity, if it has one.
+	if limit := exit.child.spec.Intensity; limit != nil {
+		var ok bool
+		exit.child.restartHistory, ok = recordRestart(exit.child.restartHistory, time.Now(), limit.MaxRestarts, limit.Window)
+		if !ok {
+			s.emitChildEvent(exit.child, Event{
+				Type: ChildFailedIntensity,
+				Err:  ErrIntensityExceeded,
+			})
+
+			if limit.OnExceeded == IntensityEscalate {
+				s.emitChildEvent(exit.child, Event{Type: SupervisorFailedIntensity})
+				return fmt.Errorf("child %s: %w", exit.child.spec.Name, ErrIntensityExceeded)
 			}
-		}
 
-		// If no children left, stop the supervisor.
-		if len(s.children) == 0 {
-			s.mu.Unlock()
-			s.cancel() // Make the supervisor exit gracefully.
+			s.dropChild(exit.child)
 			return nil
 		}
-		s.mu.Unlock()
-		return nil
 	}
 
-	// Check restart intensity to prevent restart loops.
+	// Check the supervisor-wide restart intensity to prevent restart loops.
 	if !s.checkRestartIntensity() {
-		s.emitEvent(Event{
-			Time:      time.Now(),
-			ChildName: exit.child.spec.Name,
-			Type:      SupervisorFailedIntensity,
-		})
+		s.emitChildEvent(exit.child, Event{Type: SupervisorFailedIntensity})
 		return ErrIntensityExceeded
 	}
 
-	// Apply backoff delay before restart.
-	delay := s.backoff.ComputeDelay(exit.child.restartCount)
-	if delay > 0 {
-		time.Sleep(delay)
+	// Stop the siblings the strategy restarts together with the failed child,
+	// so they don't keep running without it while it backs off.
+	exit.child.state = StateRestarting
+	group := s.affectedChildren(exit.child)
+	s.suspendChildren(group)
+
+	delay := s.backoffFor(exit.child).ComputeDelay(exit.child.restartCount)
+	if delay <= 0 {
+		return s.restartChildren(group, childExits)
+	}
+
+	// Restart from a timer so the loop keeps serving commands and exits meanwhile.
+	s.emitChildEvent(exit.child, Event{
+		Type:     BackoffScheduled,
+		Delay:    delay,
+		Strategy: strategyName(s.restartStrategy),
+	})
+	exit.child.state = StateBackingOff
+	exit.child.timer = s.after(delay, func() error {
+		return s.resumeRestart(exit.child, childExits)
+	})
+	return nil
+}
+
+// dropChild stops tracking a child that won't be restarted.
+// If it was the last child, the supervisor stops.
+func (s *Supervisor) dropChild(ch *child) {
+	s.mu.Lock()
+	s.removeChild(ch)
+
+	// If no children left, stop the supervisor. A template-based pool
+	// keeps running with no instances.
+	if (len(s.children) == 0 && s.template == nil) || s.significantExit(ch) {
+		s.cancel() // Make the supervisor exit gracefully.
+	}
+	s.mu.Unlock()
+
+	s.emitChildEvent(ch, Event{Type: ChildRemoved})
+}
+
+// significantExit reports whether the exit of ch, which has just been dropped,
+// triggers an automatic shutdown. s.mu must be held.
+func (s *Supervisor) significantExit(ch *child) bool {
+	if !ch.spec.Significant {
+		return false
+	}
+
+	switch s.autoShutdown {
+	case AutoShutdownAnySignificant:
+		return true
+	case AutoShutdownAllSignificant:
+		return !slices.ContainsFunc(s.children, func(c *child) bool { return c.spec.Significant })
+	default:
+		return false
+	}
+}
+
+// backoffFor returns the backoff policy for ch: its own, or the supervisor's.
+func (s *Supervisor) backoffFor(ch *child) BackoffPolicy {
+	if ch.spec.Backoff != nil {
+		return ch.spec.Backoff
+	}
+	return s.backoff
+}
+
+// isCurrent reports whether ch is the run the supervisor is tracking for its name
+// and was not stopped intentionally.
+func (s *Supervisor) isCurrent(ch *child) bool {
+	return !ch.isStopped() && s.isTracked(ch)
+}
+
+// isTracked reports whether ch is the run the supervisor is tracking for its name,
+// even if it was stopped, e.g. to wait for a restart.
+func (s *Supervisor) isTracked(ch *child) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.childMap[ch.spec.Name] == ch
+}
+
+// resumeRestart runs the restart strategy for failed once its backoff has elapsed.
+// The group is recomputed because children may have been added, removed or
+// restarted while it was waiting.
+func (s *Supervisor) resumeRestart(failed *child, childExits chan *childExit) error {
+	if !s.isTracked(failed) || failed.state != StateBackingOff {
+		// Removed or restarted manually in the meantime.
+		return nil
 	}
+	failed.timer = nil
 
-	// Execute the configured restart strategy.
-	return s.executeStrategy(exit, childExits)
+	return s.executeStrategy(failed, childExits)
 }
 
 // shouldRestart determines if a child should be restarted based on its restart type.
@@ -463,19 +1128,26 @@ func (s *Supervisor) shouldRestart(exit *childExit) bool {
 // checkRestartIntensity checks if restart rate is within configured limits.
 // Returns false if too many restarts have occurred in the time window.
 func (s *Supervisor) checkRestartIntensity() bool {
-	now := time.Now()
-	s.restartHistory = append(s.restartHistory, now)
+	var ok bool
+	s.restartHistory, ok = recordRestart(s.restartHistory, time.Now(), s.maxRestarts, s.restartWindow)
+	return ok
+}
+
+// recordRestart appends now to history, drops entries that fell out of the window,
+// and reports whether the restarts left are within maxRestarts.
+func recordRestart(history []time.Time, now time.Time, maxRestarts int, window time.Duration) ([]time.Time, bool) {
+	history = append(history, now)
 
 	// Remove old entries outside the window
-	cutoff := now.Add(-s.restartWindow)
+	cutoff := now.Add(-window)
 	start := 0
-	for i, t := range s.restartHistory {
+	for i, t := range history {
 		if t.After(cutoff) {
 			start = i
 			break
 		}
 	}
-	s.restartHistory = s.restartHistory[start:]
+	history = history[start:]
 
-	return len(s.restartHistory) <= s.maxRestarts
+	return history, len(history) <= maxRestarts
 }
@@ -3,6 +3,10 @@ package goverseer
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -314,6 +318,181 @@ func TestIntensityLimit(t *testing.T) {
 	}
 }
 
+// TestPerChildIntensityDrop tests that a child over its own limit is dropped while siblings keep running
+func TestPerChildIntensityDrop(t *testing.T) {
+	var flakyRuns atomic.Int32
+	var dropped atomic.Bool
+
+	flaky := func(ctx context.Context) error {
+		flakyRuns.Add(1)
+		return errors.New("always fails")
+	}
+	worker := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("child-intensity-drop-test"),
+		WithIntensity(100, time.Second),
+		WithBackoff(ConstantBackoff(5*time.Second)),
+		WithEventHandler(func(e Event) {
+			if e.Type == ChildFailedIntensity && e.ChildName == "flaky" {
+				dropped.Store(true)
+			}
+		}),
+		WithChildren(
+			ChildSpec{Name: "worker", Start: worker, Restart: Permanent},
+			ChildSpec{
+				Name:      "flaky",
+				Start:     flaky,
+				Restart:   Permanent,
+				Intensity: &Intensity{MaxRestarts: 2, Window: time.Second},
+				Backoff:   ConstantBackoff(time.Millisecond),
+			},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if flakyRuns.Load() != 3 {
+		t.Fatalf("expected flaky to run 3 times before being dropped, got %d", flakyRuns.Load())
+	}
+	if !dropped.Load() {
+		t.Fatal("ChildFailedIntensity event not received")
+	}
+
+	infos, err := sup.Children()
+	if err != nil {
+		t.Fatalf("failed to list children: %v", err)
+	}
+	if len(infos) != 1 || infos[0].Name != "worker" || infos[0].State != StateRunning {
+		t.Fatalf("expected only worker to keep running, got: %+v", infos)
+	}
+
+	if err := sup.Stop(); err != nil {
+		t.Fatalf("failed to stop supervisor: %v", err)
+	}
+}
+
+// TestPerChildIntensityEscalate tests that a child over its own limit can fail the supervisor
+func TestPerChildIntensityEscalate(t *testing.T) {
+	flaky := func(ctx context.Context) error {
+		return errors.New("always fails")
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("child-intensity-escalate-test"),
+		WithIntensity(100, time.Second),
+		WithBackoff(ConstantBackoff(time.Millisecond)),
+		WithChildren(
+			ChildSpec{
+				Name:    "flaky",
+				Start:   flaky,
+				Restart: Permanent,
+				Intensity: &Intensity{
+					MaxRestarts: 1,
+					Window:      time.Second,
+					OnExceeded:  IntensityEscalate,
+				},
+			},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	err := sup.Wait()
+	if !errors.Is(err, ErrIntensityExceeded) {
+		t.Fatalf("expected ErrIntensityExceeded, got: %v", err)
+	}
+}
+
+// TestStableAfterResetsRestartCount tests that a child running long enough has its restart count reset
+func TestStableAfterResetsRestartCount(t *testing.T) {
+	var runCount atomic.Int32
+	var failAgain atomic.Bool
+	var stable atomic.Int32
+
+	worker := func(ctx context.Context) error {
+		if runCount.Add(1) <= 3 {
+			return errors.New("early failure")
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(10 * time.Millisecond):
+				if failAgain.CompareAndSwap(true, false) {
+					return errors.New("late failure")
+				}
+			}
+		}
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("stable-after-test"),
+		WithBackoff(ConstantBackoff(time.Millisecond)),
+		WithStableAfter(100*time.Millisecond),
+		WithEventHandler(func(e Event) {
+			if e.Type == ChildStable {
+				stable.Add(1)
+			}
+		}),
+		WithChildren(
+			ChildSpec{Name: "worker", Start: worker, Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	defer sup.Stop()
+
+	time.Sleep(50 * time.Millisecond)
+
+	infos, err := sup.Children()
+	if err != nil {
+		t.Fatalf("failed to list children: %v", err)
+	}
+	if infos[0].Restarts != 3 {
+		t.Fatalf("expected 3 restarts before becoming stable, got %d", infos[0].Restarts)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	infos, err = sup.Children()
+	if err != nil {
+		t.Fatalf("failed to list children: %v", err)
+	}
+	if infos[0].Restarts != 0 {
+		t.Fatalf("expected restart count to reset, got %d", infos[0].Restarts)
+	}
+	if stable.Load() != 1 {
+		t.Fatalf("expected one ChildStable event, got %d", stable.Load())
+	}
+
+	// The next failure counts from zero again.
+	failAgain.Store(true)
+	time.Sleep(50 * time.Millisecond)
+
+	infos, err = sup.Children()
+	if err != nil {
+		t.Fatalf("failed to list children: %v", err)
+	}
+	if infos[0].Restarts != 1 {
+		t.Fatalf("expected restart count of 1 after reset, got %d", infos[0].Restarts)
+	}
+}
+
 // TestDynamicChildManagement tests adding and removing children at runtime
 func TestDynamicChildManagement(t *testing.T) {
 	worker := func(ctx context.Context) error {
@@ -492,40 +671,2178 @@ func TestShutdownTimeout(t *testing.T) {
 	}
 }
 
-// TestConcurrentOperations tests thread safety
-func TestConcurrentOperations(t *testing.T) {
+// TestShutdownWaitsForChildren tests that Stop returns only after children finish their cleanup
+func TestShutdownWaitsForChildren(t *testing.T) {
+	var cleanedUp atomic.Bool
+
 	worker := func(ctx context.Context) error {
 		<-ctx.Done()
+		time.Sleep(100 * time.Millisecond)
+		cleanedUp.Store(true)
 		return nil
 	}
 
-	sup := New(OneForOne, WithName("concurrent-test"))
+	sup := New(
+		OneForOne,
+		WithName("shutdown-wait-test"),
+		WithShutdownTimeout(time.Second),
+		WithChildren(
+			ChildSpec{Name: "worker", Start: worker, Restart: Permanent},
+		),
+	)
 
 	if err := sup.Start(); err != nil {
 		t.Fatalf("failed to start supervisor: %v", err)
 	}
 
-	// Add children concurrently
-	done := make(chan bool)
-	for i := 0; i < 10; i++ {
-		go func(id int) {
-			_ = sup.AddChild(ChildSpec{
-				Name:    "worker-" + string(rune('0'+id)),
-				Start:   worker,
-				Restart: Permanent,
-			})
-			done <- true
-		}(i)
+	time.Sleep(50 * time.Millisecond)
+
+	if err := sup.Stop(); err != nil {
+		t.Fatalf("failed to stop supervisor: %v", err)
 	}
 
-	for i := 0; i < 10; i++ {
-		<-done
+	if !cleanedUp.Load() {
+		t.Fatal("Stop returned before the child finished cleaning up")
+	}
+}
+
+// TestShutdownTimeoutReportsChildren tests that children missing the deadline are reported
+func TestShutdownTimeoutReportsChildren(t *testing.T) {
+	var timedOut atomic.Value
+
+	slow := func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(time.Second)
+		return nil
+	}
+	fast := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("shutdown-report-test"),
+		WithShutdownTimeout(100*time.Millisecond),
+		WithEventHandler(func(e Event) {
+			if e.Type == ChildShutdownTimeout {
+				timedOut.Store(e.ChildName)
+			}
+		}),
+		WithChildren(
+			ChildSpec{Name: "fast-worker", Start: fast, Restart: Permanent},
+			ChildSpec{Name: "slow-worker", Start: slow, Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	err := sup.Stop()
+	if !errors.Is(err, ErrShutdownTimeout) {
+		t.Fatalf("expected ErrShutdownTimeout, got: %v", err)
+	}
+
+	var timeoutErr *ShutdownTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected *ShutdownTimeoutError, got: %T", err)
+	}
+	if len(timeoutErr.Children) != 1 || timeoutErr.Children[0] != "slow-worker" {
+		t.Fatalf("expected only slow-worker to miss the deadline, got: %v", timeoutErr.Children)
+	}
+
+	if name, _ := timedOut.Load().(string); name != "slow-worker" {
+		t.Fatalf("expected ChildShutdownTimeout for slow-worker, got: %q", name)
+	}
+
+	if err := sup.Wait(); !errors.Is(err, ErrShutdownTimeout) {
+		t.Fatalf("expected Wait to report ErrShutdownTimeout, got: %v", err)
+	}
+}
+
+// TestPerChildShutdownPolicy tests that ChildSpec.Shutdown overrides the supervisor timeout
+func TestPerChildShutdownPolicy(t *testing.T) {
+	var drained atomic.Bool
+
+	slowCleanup := func(d time.Duration, flag *atomic.Bool) ChildFunc {
+		return func(ctx context.Context) error {
+			<-ctx.Done()
+			time.Sleep(d)
+			if flag != nil {
+				flag.Store(true)
+			}
+			return nil
+		}
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("shutdown-policy-test"),
+		WithShutdownTimeout(50*time.Millisecond),
+		WithChildren(
+			ChildSpec{Name: "db-pool", Start: slowCleanup(200*time.Millisecond, &drained), Restart: Permanent, Shutdown: ShutdownInfinity},
+			ChildSpec{Name: "ticker", Start: slowCleanup(time.Second, nil), Restart: Permanent, Shutdown: ShutdownBrutalKill},
+			ChildSpec{Name: "cache", Start: slowCleanup(time.Second, nil), Restart: Permanent, Shutdown: 100 * time.Millisecond},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	start := time.Now()
+	err := sup.Stop()
+	elapsed := time.Since(start)
+
+	if !drained.Load() {
+		t.Fatal("db-pool should have been given unlimited time to drain")
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Fatalf("shutdown took too long: %v", elapsed)
+	}
+
+	var timeoutErr *ShutdownTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected *ShutdownTimeoutError, got: %v", err)
+	}
+	if len(timeoutErr.Children) != 1 || timeoutErr.Children[0] != "cache" {
+		t.Fatalf("expected only cache to miss its deadline, got: %v", timeoutErr.Children)
+	}
+}
+
+// TestRemoveChildHonorsShutdown tests that RemoveChild waits for the child within its shutdown policy
+func TestRemoveChildHonorsShutdown(t *testing.T) {
+	var cleanedUp atomic.Bool
+
+	worker := func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+		cleanedUp.Store(true)
+		return nil
+	}
+
+	sup := New(OneForOne, WithName("remove-shutdown-test"))
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	if err := sup.AddChild(ChildSpec{Name: "worker", Start: worker, Restart: Permanent, Shutdown: time.Second}); err != nil {
+		t.Fatalf("failed to add child: %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if err := sup.RemoveChild("worker"); err != nil {
+		t.Fatalf("failed to remove child: %v", err)
+	}
+
+	if !cleanedUp.Load() {
+		t.Fatal("RemoveChild returned before the child finished cleaning up")
 	}
 
-	time.Sleep(100 * time.Millisecond)
 	sup.Stop()
 }
 
+// TestOrderedShutdown tests that children are stopped one at a time in reverse start order
+func TestOrderedShutdown(t *testing.T) {
+	var mu sync.Mutex
+	var stopped []string
+
+	worker := func(name string) ChildFunc {
+		return func(ctx context.Context) error {
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			stopped = append(stopped, name)
+			mu.Unlock()
+			return nil
+		}
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("ordered-shutdown-test"),
+		WithChildren(
+			ChildSpec{Name: "db-pool", Start: worker("db-pool"), Restart: Permanent},
+			ChildSpec{Name: "cache", Start: worker("cache"), Restart: Permanent},
+			ChildSpec{Name: "http-server", Start: worker("http-server"), Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if err := sup.Stop(); err != nil {
+		t.Fatalf("failed to stop supervisor: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"http-server", "cache", "db-pool"}
+	if !slices.Equal(stopped, want) {
+		t.Fatalf("expected stop order %v, got %v", want, stopped)
+	}
+}
+
+// TestParallelShutdown tests that WithParallelShutdown stops all children at once
+func TestParallelShutdown(t *testing.T) {
+	worker := func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("parallel-shutdown-test"),
+		WithParallelShutdown(),
+		WithChildren(
+			ChildSpec{Name: "worker-1", Start: worker, Restart: Permanent},
+			ChildSpec{Name: "worker-2", Start: worker, Restart: Permanent},
+			ChildSpec{Name: "worker-3", Start: worker, Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	start := time.Now()
+	if err := sup.Stop(); err != nil {
+		t.Fatalf("failed to stop supervisor: %v", err)
+	}
+
+	// Sequential shutdown would take at least 300ms
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Fatalf("parallel shutdown took too long: %v", elapsed)
+	}
+}
+
+// TestBackoffDoesNotBlockSupervisor tests that commands and Stop are served while a child backs off
+func TestBackoffDoesNotBlockSupervisor(t *testing.T) {
+	var failed atomic.Bool
+
+	flaky := func(ctx context.Context) error {
+		failed.Store(true)
+		return errors.New("boom")
+	}
+	worker := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("backoff-nonblocking-test"),
+		WithBackoff(ConstantBackoff(5*time.Second)),
+		WithChildren(
+			ChildSpec{Name: "flaky", Start: flaky, Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	// Let flaky fail and enter its backoff.
+	time.Sleep(50 * time.Millisecond)
+	if !failed.Load() {
+		t.Fatal("flaky child did not run")
+	}
+
+	start := time.Now()
+
+	if err := sup.AddChild(ChildSpec{Name: "worker", Start: worker, Restart: Permanent}); err != nil {
+		t.Fatalf("failed to add child: %v", err)
+	}
+	if err := sup.RemoveChild("worker"); err != nil {
+		t.Fatalf("failed to remove child: %v", err)
+	}
+	if err := sup.Stop(); err != nil {
+		t.Fatalf("failed to stop supervisor: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("supervisor was blocked by backoff for %v", elapsed)
+	}
+}
+
+// TestChildrenIntrospection tests the Children and Count snapshots
+func TestChildrenIntrospection(t *testing.T) {
+	worker := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+	flaky := func(ctx context.Context) error {
+		return errors.New("flaky error")
+	}
+
+	sub := New(OneForOne, WithName("sub"), WithChildren(
+		ChildSpec{Name: "sub-worker", Start: worker, Restart: Permanent},
+	))
+
+	sup := New(
+		OneForOne,
+		WithName("introspection-test"),
+		WithBackoff(ConstantBackoff(5*time.Second)),
+		WithChildren(
+			ChildSpec{Name: "worker", Start: worker, Restart: Permanent},
+			ChildSpec{Name: "flaky", Start: flaky, Restart: Transient},
+			ChildSpec{Name: "sub", Supervisor: sub, Restart: Permanent},
+		),
+	)
+
+	infos, err := sup.Children()
+	if err != nil {
+		t.Fatalf("failed to list children before start: %v", err)
+	}
+	if len(infos) != 3 || infos[0].State != StateStopped {
+		t.Fatalf("expected 3 stopped children before start, got: %+v", infos)
+	}
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	defer sup.Stop()
+
+	time.Sleep(50 * time.Millisecond)
+
+	infos, err = sup.Children()
+	if err != nil {
+		t.Fatalf("failed to list children: %v", err)
+	}
+
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, info.Name)
+	}
+	if !slices.Equal(names, []string{"worker", "flaky", "sub"}) {
+		t.Fatalf("unexpected children: %v", names)
+	}
+
+	if infos[0].State != StateRunning || infos[0].Uptime <= 0 || infos[0].StartedAt.IsZero() {
+		t.Fatalf("worker should be running with uptime, got: %+v", infos[0])
+	}
+	if infos[1].State != StateBackingOff || infos[1].LastError == nil || infos[1].Uptime != 0 {
+		t.Fatalf("flaky should be backing off with its last error, got: %+v", infos[1])
+	}
+	if infos[1].Restart != Transient {
+		t.Fatalf("flaky should report its restart type, got: %v", infos[1].Restart)
+	}
+	if !infos[2].Supervisor {
+		t.Fatalf("sub should be reported as a supervisor, got: %+v", infos[2])
+	}
+
+	count, err := sup.Count()
+	if err != nil {
+		t.Fatalf("failed to count children: %v", err)
+	}
+	want := ChildCount{Specs: 3, Active: 2, Supervisors: 1, Workers: 2}
+	if count != want {
+		t.Fatalf("expected count %+v, got %+v", want, count)
+	}
+}
+
+// TestCallsAfterStop tests that management calls on a stopped supervisor return immediately
+func TestCallsAfterStop(t *testing.T) {
+	worker := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("calls-after-stop-test"),
+		WithChildren(ChildSpec{Name: "worker", Start: worker, Restart: Permanent}),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	if err := sup.Stop(); err != nil {
+		t.Fatalf("failed to stop supervisor: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// More calls than the command buffer holds
+		for i := 0; i < 50; i++ {
+			if err := sup.AddChild(ChildSpec{Name: "late", Start: worker}); !errors.Is(err, ErrSupervisorStopped) {
+				t.Errorf("AddChild: expected ErrSupervisorStopped, got: %v", err)
+			}
+			if err := sup.RemoveChild("worker"); !errors.Is(err, ErrSupervisorStopped) {
+				t.Errorf("RemoveChild: expected ErrSupervisorStopped, got: %v", err)
+			}
+			if err := sup.RestartChild("worker"); !errors.Is(err, ErrSupervisorStopped) {
+				t.Errorf("RestartChild: expected ErrSupervisorStopped, got: %v", err)
+			}
+			if _, err := sup.Children(); !errors.Is(err, ErrSupervisorStopped) {
+				t.Errorf("Children: expected ErrSupervisorStopped, got: %v", err)
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("management calls hung on a stopped supervisor")
+	}
+
+	if err := sup.Start(); !errors.Is(err, ErrSupervisorStopped) {
+		t.Fatalf("Start: expected ErrSupervisorStopped, got: %v", err)
+	}
+}
+
+// TestCallsRacingShutdown tests that calls made while the supervisor shuts down never hang
+func TestCallsRacingShutdown(t *testing.T) {
+	worker := func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	}
+
+	for round := 0; round < 10; round++ {
+		sup := New(OneForOne, WithName("calls-racing-shutdown-test"))
+		if err := sup.Start(); err != nil {
+			t.Fatalf("failed to start supervisor: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(id int) {
+				defer wg.Done()
+				name := fmt.Sprintf("worker-%d", id)
+				err := sup.AddChild(ChildSpec{Name: name, Start: worker, Restart: Permanent})
+				if err != nil && !errors.Is(err, ErrSupervisorStopped) {
+					t.Errorf("AddChild: unexpected error: %v", err)
+				}
+				err = sup.RestartChild(name)
+				if err != nil && !errors.Is(err, ErrSupervisorStopped) && !errors.Is(err, ErrChildNotFound) {
+					t.Errorf("RestartChild: unexpected error: %v", err)
+				}
+				if _, err := sup.Children(); err != nil && !errors.Is(err, ErrSupervisorStopped) {
+					t.Errorf("Children: unexpected error: %v", err)
+				}
+			}(i)
+		}
+
+		time.Sleep(time.Duration(round) * time.Millisecond)
+		sup.Stop()
+
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("management calls hung while racing shutdown")
+		}
+	}
+}
+
+// TestCallContextCanceled tests that context variants give up when their context is done
+func TestCallContextCanceled(t *testing.T) {
+	slow := func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(300 * time.Millisecond)
+		return nil
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("call-context-test"),
+		WithChildren(ChildSpec{Name: "slow", Start: slow, Restart: Permanent, Shutdown: time.Second}),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	defer sup.Stop()
+
+	// Keep the loop busy waiting for slow to stop
+	go sup.RemoveChild("slow")
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := sup.ChildrenContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Fatalf("call did not honor its context, took %v", elapsed)
+	}
+}
+
+// TestCancellationCauses tests that children can tell why they were stopped
+func TestCancellationCauses(t *testing.T) {
+	var mu sync.Mutex
+	causes := make(map[string][]error)
+	record := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			<-ctx.Done()
+			mu.Lock()
+			causes[name] = append(causes[name], context.Cause(ctx))
+			mu.Unlock()
+			return nil
+		}
+	}
+
+	var failOnce atomic.Bool
+	failing := func(ctx context.Context) error {
+		if failOnce.CompareAndSwap(false, true) {
+			time.Sleep(20 * time.Millisecond)
+			return errors.New("first run fails")
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	sup := New(
+		OneForAll,
+		WithName("cause-test"),
+		WithBackoff(ConstantBackoff(0)),
+		WithChildren(
+			ChildSpec{Name: "sibling", Start: record("sibling"), Restart: Permanent},
+			ChildSpec{Name: "failing", Start: failing, Restart: Permanent},
+			ChildSpec{Name: "restarted", Start: record("restarted"), Restart: Permanent},
+			ChildSpec{Name: "removed", Start: record("removed"), Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	if err := sup.RestartChild("restarted"); err != nil {
+		t.Fatalf("failed to restart child: %v", err)
+	}
+	if err := sup.RemoveChild("removed"); err != nil {
+		t.Fatalf("failed to remove child: %v", err)
+	}
+	sup.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	want := map[string][]error{
+		"sibling":   {ErrSiblingFailed, ErrShutdown},
+		"restarted": {ErrSiblingFailed, ErrManualRestart, ErrShutdown},
+		"removed":   {ErrSiblingFailed, ErrChildRemoved},
+	}
+	for name, expected := range want {
+		got := causes[name]
+		if len(got) != len(expected) {
+			t.Fatalf("%s: expected causes %v, got %v", name, expected, got)
+		}
+		for i := range expected {
+			if !errors.Is(got[i], expected[i]) {
+				t.Fatalf("%s: expected causes %v, got %v", name, expected, got)
+			}
+		}
+	}
+}
+
+// TestExitReasons tests that exit events say why the child exited
+func TestExitReasons(t *testing.T) {
+	var mu sync.Mutex
+	reasons := make(map[string][]ExitReason)
+
+	block := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+	stuck := func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(500 * time.Millisecond)
+		return nil
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("reason-test"),
+		WithShutdownTimeout(50*time.Millisecond),
+		WithEventHandler(func(e Event) {
+			if e.Reason == ExitNone {
+				return
+			}
+			mu.Lock()
+			reasons[e.ChildName] = append(reasons[e.ChildName], e.Reason)
+			mu.Unlock()
+		}),
+		WithChildren(
+			ChildSpec{Name: "shutdown", Start: block, Restart: Permanent},
+			ChildSpec{Name: "killed", Start: stuck, Restart: Permanent, Shutdown: ShutdownBrutalKill},
+			ChildSpec{Name: "timeout", Start: stuck, Restart: Permanent},
+			ChildSpec{Name: "normal", Start: func(ctx context.Context) error { return nil }, Restart: Temporary},
+			ChildSpec{Name: "error", Start: func(ctx context.Context) error { return errors.New("boom") }, Restart: Temporary},
+			ChildSpec{Name: "panic", Start: func(ctx context.Context) error { panic("boom") }, Restart: Temporary},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	sup.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	want := map[string]ExitReason{
+		"normal":   ExitNormal,
+		"error":    ExitError,
+		"panic":    ExitPanic,
+		"shutdown": ExitShutdown,
+		"killed":   ExitKilled,
+		"timeout":  ExitTimeout,
+	}
+	for name, reason := range want {
+		if got := reasons[name]; len(got) != 1 || got[0] != reason {
+			t.Fatalf("%s: expected reason %v, got %v", name, reason, got)
+		}
+	}
+}
+
+// TestReadinessHandshake tests that Start waits for each child to report ready, in order
+func TestReadinessHandshake(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	record := func(s string) {
+		mu.Lock()
+		order = append(order, s)
+		mu.Unlock()
+	}
+
+	slowInit := func(name string) ChildFunc {
+		return func(ctx context.Context) error {
+			record(name + " starting")
+			time.Sleep(50 * time.Millisecond)
+			record(name + " ready")
+			Ready(ctx)
+			<-ctx.Done()
+			return nil
+		}
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("readiness-test"),
+		WithChildren(
+			ChildSpec{Name: "db", Start: slowInit("db"), Restart: Permanent, StartTimeout: time.Second},
+			ChildSpec{Name: "api", Start: slowInit("api"), Restart: Permanent, StartTimeout: time.Second},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	defer sup.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := []string{"db starting", "db ready", "api starting", "api ready"}
+	if !slices.Equal(order, expected) {
+		t.Fatalf("expected start order %v, got %v", expected, order)
+	}
+}
+
+// TestReadinessTimeout tests that Start fails, naming the child, when a child never reports ready
+func TestReadinessTimeout(t *testing.T) {
+	var laterStarted atomic.Bool
+
+	sup := New(
+		OneForOne,
+		WithName("readiness-timeout-test"),
+		WithChildren(
+			ChildSpec{
+				Name:         "hanging",
+				Start:        func(ctx context.Context) error { <-ctx.Done(); return nil },
+				Restart:      Permanent,
+				StartTimeout: 50 * time.Millisecond,
+			},
+			ChildSpec{
+				Name:    "later",
+				Start:   func(ctx context.Context) error { laterStarted.Store(true); <-ctx.Done(); return nil },
+				Restart: Permanent,
+			},
+		),
+	)
+
+	err := sup.Start()
+	if !errors.Is(err, ErrStartTimeout) {
+		t.Fatalf("expected ErrStartTimeout, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "hanging") {
+		t.Fatalf("expected error to name the child, got: %v", err)
+	}
+	if laterStarted.Load() {
+		t.Fatal("children after the failed one should not be started")
+	}
+	if err := sup.AddChild(ChildSpec{Name: "x", Start: func(ctx context.Context) error { return nil }}); !errors.Is(err, ErrSupervisorStopped) {
+		t.Fatalf("supervisor should be stopped after a failed start, got: %v", err)
+	}
+}
+
+// TestReadinessExitBeforeReady tests that AddChild fails when a child returns before it is ready
+func TestReadinessExitBeforeReady(t *testing.T) {
+	sup := New(
+		OneForOne,
+		WithName("readiness-exit-test"),
+		WithChildren(ChildSpec{Name: "anchor", Start: func(ctx context.Context) error { <-ctx.Done(); return nil }, Restart: Permanent}),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	defer sup.Stop()
+
+	connErr := errors.New("connection refused")
+	err := sup.AddChild(ChildSpec{
+		Name:         "client",
+		Start:        func(ctx context.Context) error { return connErr },
+		Restart:      Permanent,
+		StartTimeout: time.Second,
+	})
+	if !errors.Is(err, ErrExitedBeforeReady) || !errors.Is(err, connErr) {
+		t.Fatalf("expected ErrExitedBeforeReady joined with the child's error, got: %v", err)
+	}
+
+	count, err := sup.Count()
+	if err != nil {
+		t.Fatalf("failed to count children: %v", err)
+	}
+	if count.Specs != 1 {
+		t.Fatalf("a child that failed to start should not be kept, got %d specs", count.Specs)
+	}
+}
+
+// TestReadinessTimeoutOnRestart tests that a restarted child that never becomes ready counts as failed
+func TestReadinessTimeoutOnRestart(t *testing.T) {
+	var runs atomic.Int32
+
+	worker := func(ctx context.Context) error {
+		switch runs.Add(1) {
+		case 1:
+			Ready(ctx)
+			return errors.New("crash")
+		case 2:
+			// Hangs during init
+			<-ctx.Done()
+			return nil
+		default:
+			Ready(ctx)
+			<-ctx.Done()
+			return nil
+		}
+	}
+
+	var timeouts atomic.Int32
+	sup := New(
+		OneForOne,
+		WithName("readiness-restart-test"),
+		WithBackoff(ConstantBackoff(0)),
+		WithEventHandler(func(e Event) {
+			if e.Type == ChildExited && errors.Is(e.Err, ErrStartTimeout) {
+				timeouts.Add(1)
+			}
+		}),
+		WithChildren(ChildSpec{Name: "worker", Start: worker, Restart: Permanent, StartTimeout: 50 * time.Millisecond}),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	defer sup.Stop()
+
+	time.Sleep(200 * time.Millisecond)
+
+	if runs.Load() != 3 {
+		t.Fatalf("expected 3 runs, got %d", runs.Load())
+	}
+	if timeouts.Load() != 1 {
+		t.Fatalf("expected 1 start timeout failure, got %d", timeouts.Load())
+	}
+}
+
+// TestHealthCheckRestartsUnhealthyChild tests that a child failing its health check is restarted
+func TestHealthCheckRestartsUnhealthyChild(t *testing.T) {
+	var runs atomic.Int32
+	var healthy atomic.Bool
+
+	// Deadlocks on its first run: it never returns on its own but stops answering probes
+	worker := func(ctx context.Context) error {
+		if runs.Add(1) == 1 {
+			healthy.Store(false)
+		} else {
+			healthy.Store(true)
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	var mu sync.Mutex
+	var causes []error
+	var failed, exited atomic.Int32
+	sup := New(
+		OneForOne,
+		WithName("health-test"),
+		WithBackoff(ConstantBackoff(0)),
+		WithEventHandler(func(e Event) {
+			switch e.Type {
+			case ChildHealthCheckFailed:
+				failed.Add(1)
+			case ChildExited:
+				if errors.Is(e.Err, ErrUnhealthy) {
+					exited.Add(1)
+				}
+			}
+		}),
+		WithChildren(ChildSpec{
+			Name: "worker",
+			Start: func(ctx context.Context) error {
+				err := worker(ctx)
+				mu.Lock()
+				causes = append(causes, context.Cause(ctx))
+				mu.Unlock()
+				return err
+			},
+			Restart: Permanent,
+			HealthCheck: func(ctx context.Context) error {
+				if !healthy.Load() {
+					return errors.New("not responding")
+				}
+				return nil
+			},
+			HealthInterval:         20 * time.Millisecond,
+			HealthFailureThreshold: 2,
+		}),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(150 * time.Millisecond)
+	sup.Stop()
+
+	if runs.Load() != 2 {
+		t.Fatalf("expected the unhealthy child to be restarted once, got %d runs", runs.Load())
+	}
+	if failed.Load() != 2 {
+		t.Fatalf("expected 2 failed health checks, got %d", failed.Load())
+	}
+	if exited.Load() != 1 {
+		t.Fatalf("expected 1 exit with ErrUnhealthy, got %d", exited.Load())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(causes) == 0 || !errors.Is(causes[0], ErrUnhealthy) {
+		t.Fatalf("expected the unhealthy child to be canceled with ErrUnhealthy, got %v", causes)
+	}
+}
+
+// TestHealthCheckRecovery tests that a health check passing again below the threshold is reported
+func TestHealthCheckRecovery(t *testing.T) {
+	var probes atomic.Int32
+	var failed, recovered atomic.Int32
+
+	sup := New(
+		OneForOne,
+		WithName("health-recovery-test"),
+		WithEventHandler(func(e Event) {
+			switch e.Type {
+			case ChildHealthCheckFailed:
+				failed.Add(1)
+			case ChildHealthCheckRecovered:
+				recovered.Add(1)
+			}
+		}),
+		WithChildren(ChildSpec{
+			Name:    "worker",
+			Start:   func(ctx context.Context) error { <-ctx.Done(); return nil },
+			Restart: Permanent,
+			HealthCheck: func(ctx context.Context) error {
+				if probes.Add(1) == 1 {
+					return errors.New("slow")
+				}
+				return nil
+			},
+			HealthInterval: 20 * time.Millisecond,
+		}),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	infos, err := sup.Children()
+	if err != nil {
+		t.Fatalf("failed to list children: %v", err)
+	}
+	sup.Stop()
+
+	if failed.Load() != 1 || recovered.Load() != 1 {
+		t.Fatalf("expected 1 failure and 1 recovery, got %d and %d", failed.Load(), recovered.Load())
+	}
+	if infos[0].Restarts != 0 {
+		t.Fatalf("a recovered child should not be restarted, got %d restarts", infos[0].Restarts)
+	}
+}
+
+// TestHealthCheckRestartedChild tests that a child's health check doesn't outlive its run
+func TestHealthCheckRestartedChild(t *testing.T) {
+	var runs, probes atomic.Int32
+	worker := func(ctx context.Context) error {
+		if runs.Add(1) <= 5 {
+			time.Sleep(15 * time.Millisecond)
+			return nil
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("health-restart-test"),
+		WithIntensity(10, time.Second),
+		WithBackoff(ConstantBackoff(0)),
+		WithChildren(ChildSpec{
+			Name:    "worker",
+			Start:   worker,
+			Restart: Permanent,
+			HealthCheck: func(ctx context.Context) error {
+				probes.Add(1)
+				return nil
+			},
+			HealthInterval: 10 * time.Millisecond,
+		}),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(150 * time.Millisecond)
+
+	// Only the live run should be probed, about once per interval.
+	before := probes.Load()
+	time.Sleep(100 * time.Millisecond)
+	if rate := probes.Load() - before; rate > 13 {
+		t.Fatalf("expected about 10 probes per 100ms, got %d", rate)
+	}
+
+	sup.Stop()
+	after := probes.Load()
+	time.Sleep(50 * time.Millisecond)
+	if probes.Load() != after {
+		t.Fatal("expected no probes after Stop")
+	}
+}
+
+// hangForever blocks, ignoring its context, until release is closed.
+func hangForever(release chan struct{}) {
+	<-release
+}
+
+// TestWatchdogReplacesHungChild tests that a child that stops sending heartbeats is abandoned and replaced
+func TestWatchdogReplacesHungChild(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	var runs atomic.Int32
+	worker := func(ctx context.Context) error {
+		if runs.Add(1) == 1 {
+			Heartbeat(ctx)
+			hangForever(release)
+			return nil
+		}
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				Heartbeat(ctx)
+			}
+		}
+	}
+
+	var mu sync.Mutex
+	var hung []Event
+	var timeouts atomic.Int32
+	sup := New(
+		OneForOne,
+		WithName("watchdog-test"),
+		WithBackoff(ConstantBackoff(0)),
+		WithEventHandler(func(e Event) {
+			switch e.Type {
+			case ChildHung:
+				mu.Lock()
+				hung = append(hung, e)
+				mu.Unlock()
+			case ChildShutdownTimeout:
+				timeouts.Add(1)
+			}
+		}),
+		WithChildren(ChildSpec{
+			Name:             "worker",
+			Start:            worker,
+			Restart:          Permanent,
+			Shutdown:         30 * time.Millisecond,
+			WatchdogInterval: 40 * time.Millisecond,
+		}),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	infos, err := sup.Children()
+	if err != nil {
+		t.Fatalf("failed to list children: %v", err)
+	}
+	sup.Stop()
+
+	if runs.Load() != 2 {
+		t.Fatalf("expected the hung child to be replaced once, got %d runs", runs.Load())
+	}
+	if infos[0].State != StateRunning || !errors.Is(infos[0].LastError, ErrChildHung) {
+		t.Fatalf("expected a running replacement after ErrChildHung, got %+v", infos[0])
+	}
+	if timeouts.Load() != 1 {
+		t.Fatalf("expected the hung goroutine to miss its shutdown budget, got %d timeouts", timeouts.Load())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(hung) != 1 {
+		t.Fatalf("expected 1 ChildHung event, got %d", len(hung))
+	}
+	if !strings.Contains(hung[0].StackTrace, "hangForever") {
+		t.Fatalf("expected the stack of the hung goroutine, got:\n%s", hung[0].StackTrace)
+	}
+}
+
+// TestWatchdogDoesNotBlockLoop tests that the loop keeps serving while a hung child
+// uses up its shutdown budget, and that the child is only replaced after that
+func TestWatchdogDoesNotBlockLoop(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	var runs, timeouts atomic.Int32
+	worker := func(ctx context.Context) error {
+		if runs.Add(1) == 1 {
+			Heartbeat(ctx)
+			hangForever(release)
+			return nil
+		}
+		ticker := time.NewTicker(5 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				Heartbeat(ctx)
+			}
+		}
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("watchdog-loop-test"),
+		WithBackoff(ConstantBackoff(0)),
+		WithEventHandler(func(e Event) {
+			if e.Type == ChildShutdownTimeout {
+				timeouts.Add(1)
+			}
+		}),
+		WithChildren(ChildSpec{
+			Name:             "worker",
+			Start:            worker,
+			Restart:          Permanent,
+			Shutdown:         300 * time.Millisecond,
+			WatchdogInterval: 20 * time.Millisecond,
+		}),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	defer sup.Stop()
+	time.Sleep(100 * time.Millisecond)
+
+	start := time.Now()
+	if _, err := sup.Children(); err != nil {
+		t.Fatalf("failed to list children: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("expected the loop to stay responsive, Children took %v", elapsed)
+	}
+	if runs.Load() != 1 {
+		t.Fatalf("expected no replacement within the shutdown budget, got %d runs", runs.Load())
+	}
+
+	time.Sleep(350 * time.Millisecond)
+	if runs.Load() != 2 {
+		t.Fatalf("expected the hung child to be replaced after its shutdown budget, got %d runs", runs.Load())
+	}
+	if timeouts.Load() != 1 {
+		t.Fatalf("expected 1 ChildShutdownTimeout event, got %d", timeouts.Load())
+	}
+}
+
+// TestFailedChildDrainsBeforeReplacement tests that an unhealthy child is replaced
+// only once it has returned, and that Stop waits for a child still draining
+func TestFailedChildDrainsBeforeReplacement(t *testing.T) {
+	var live, maxLive atomic.Int32
+	worker := func(ctx context.Context) error {
+		n := live.Add(1)
+		defer live.Add(-1)
+		for {
+			m := maxLive.Load()
+			if n <= m || maxLive.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		<-ctx.Done()
+		time.Sleep(100 * time.Millisecond) // drain
+		return nil
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("drain-test"),
+		WithIntensity(100, time.Second),
+		WithBackoff(ConstantBackoff(0)),
+		WithChildren(ChildSpec{
+			Name:                   "worker",
+			Start:                  worker,
+			Restart:                Permanent,
+			Shutdown:               time.Second,
+			HealthCheck:            func(ctx context.Context) error { return errors.New("unhealthy") },
+			HealthInterval:         20 * time.Millisecond,
+			HealthFailureThreshold: 1,
+		}),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(400 * time.Millisecond)
+
+	if err := sup.Stop(); err != nil {
+		t.Fatalf("unexpected error from Stop: %v", err)
+	}
+	if maxLive.Load() != 1 {
+		t.Fatalf("expected runs of the child never to overlap, got %d at once", maxLive.Load())
+	}
+	if live.Load() != 0 {
+		t.Fatalf("expected Stop to wait for the draining run, %d still running", live.Load())
+	}
+}
+
+// TestDependencyOrder tests that children start after their dependencies and stop before them
+func TestDependencyOrder(t *testing.T) {
+	// Readiness makes each start finish before the next one begins
+	var mu sync.Mutex
+	var started, stopped []string
+	worker := func(ctx context.Context) error {
+		name := ChildName(ctx)
+		mu.Lock()
+		started = append(started, name)
+		mu.Unlock()
+		Ready(ctx)
+		<-ctx.Done()
+		mu.Lock()
+		stopped = append(stopped, name)
+		mu.Unlock()
+		return nil
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("dependency-order-test"),
+		WithChildren(
+			ChildSpec{Name: "api", Start: worker, Restart: Permanent, StartTimeout: time.Second, DependsOn: []string{"cache", "db"}},
+			ChildSpec{Name: "cache", Start: worker, Restart: Permanent, StartTimeout: time.Second, DependsOn: []string{"db"}},
+			ChildSpec{Name: "metrics", Start: worker, Restart: Permanent, StartTimeout: time.Second},
+			ChildSpec{Name: "db", Start: worker, Restart: Permanent, StartTimeout: time.Second},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	sup.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if expected := []string{"metrics", "db", "cache", "api"}; !slices.Equal(started, expected) {
+		t.Fatalf("expected start order %v, got %v", expected, started)
+	}
+	if expected := []string{"api", "cache", "db", "metrics"}; !slices.Equal(stopped, expected) {
+		t.Fatalf("expected stop order %v, got %v", expected, stopped)
+	}
+}
+
+// TestDependencyRestartsDependents tests that a failed child restarts only its transitive dependents
+func TestDependencyRestartsDependents(t *testing.T) {
+	var restarts restartCounter
+	var cacheRuns atomic.Int32
+
+	block := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+	cache := func(ctx context.Context) error {
+		if cacheRuns.Add(1) == 1 {
+			time.Sleep(20 * time.Millisecond)
+			return errors.New("cache crashed")
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("dependency-restart-test"),
+		WithBackoff(ConstantBackoff(0)),
+		WithEventHandler(restarts.handle),
+		WithChildren(
+			ChildSpec{Name: "db", Start: block, Restart: Permanent},
+			ChildSpec{Name: "cache", Start: cache, Restart: Permanent, DependsOn: []string{"db"}},
+			ChildSpec{Name: "api", Start: block, Restart: Permanent, DependsOn: []string{"cache"}},
+			ChildSpec{Name: "admin", Start: block, Restart: Permanent, DependsOn: []string{"api"}},
+			ChildSpec{Name: "metrics", Start: block, Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	sup.Stop()
+
+	for name, expected := range map[string]int{"db": 0, "cache": 1, "api": 1, "admin": 1, "metrics": 0} {
+		if got := restarts.get(name); got != expected {
+			t.Errorf("%s: expected %d restarts, got %d", name, expected, got)
+		}
+	}
+}
+
+// TestDependencyErrors tests that missing dependencies and cycles are rejected
+func TestDependencyErrors(t *testing.T) {
+	block := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+
+	missing := New(
+		OneForOne,
+		WithChildren(ChildSpec{Name: "api", Start: block, DependsOn: []string{"db"}}),
+	)
+	if err := missing.Start(); !errors.Is(err, ErrMissingDependency) {
+		t.Fatalf("expected ErrMissingDependency, got: %v", err)
+	}
+
+	cycle := New(
+		OneForOne,
+		WithChildren(
+			ChildSpec{Name: "a", Start: block, DependsOn: []string{"c"}},
+			ChildSpec{Name: "b", Start: block, DependsOn: []string{"a"}},
+			ChildSpec{Name: "c", Start: block, DependsOn: []string{"b"}},
+		),
+	)
+	if err := cycle.Start(); !errors.Is(err, ErrDependencyCycle) {
+		t.Fatalf("expected ErrDependencyCycle, got: %v", err)
+	}
+
+	sup := New(
+		OneForOne,
+		WithChildren(
+			ChildSpec{Name: "db", Start: block, Restart: Permanent},
+			ChildSpec{Name: "api", Start: block, Restart: Permanent, DependsOn: []string{"db"}},
+		),
+	)
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	defer sup.Stop()
+
+	if err := sup.AddChild(ChildSpec{Name: "web", Start: block, DependsOn: []string{"auth"}}); !errors.Is(err, ErrMissingDependency) {
+		t.Fatalf("expected ErrMissingDependency, got: %v", err)
+	}
+	if err := sup.RemoveChild("db"); err != nil {
+		t.Fatalf("failed to remove child: %v", err)
+	}
+	if err := sup.AddChild(ChildSpec{Name: "db", Start: block, DependsOn: []string{"api"}}); !errors.Is(err, ErrDependencyCycle) {
+		t.Fatalf("expected ErrDependencyCycle, got: %v", err)
+	}
+}
+
+// TestAutoShutdownAnySignificant tests that a job subtree stops cleanly when its main worker completes
+func TestAutoShutdownAnySignificant(t *testing.T) {
+	var helperStopped atomic.Bool
+	var jobExit atomic.Value
+
+	job := New(
+		OneForAll,
+		WithName("job"),
+		WithAutoShutdown(AutoShutdownAnySignificant),
+		WithChildren(
+			ChildSpec{
+				Name: "main",
+				Start: func(ctx context.Context) error {
+					time.Sleep(30 * time.Millisecond)
+					return nil
+				},
+				Restart:     Transient,
+				Significant: true,
+			},
+			ChildSpec{
+				Name: "helper",
+				Start: func(ctx context.Context) error {
+					<-ctx.Done()
+					helperStopped.Store(true)
+					return nil
+				},
+				Restart: Permanent,
+			},
+		),
+	)
+
+	var restarts restartCounter
+	root := New(
+		OneForOne,
+		WithName("root"),
+		WithEventHandler(func(e Event) {
+			restarts.handle(e)
+			if e.ChildName == "job" && e.Type == ChildExited {
+				jobExit.Store(e.Reason)
+			}
+		}),
+		WithChildren(
+			ChildSpec{Name: "job", Supervisor: job, Restart: Transient, Shutdown: ShutdownInfinity},
+			ChildSpec{Name: "other", Start: func(ctx context.Context) error { <-ctx.Done(); return nil }, Restart: Permanent},
+		),
+	)
+
+	if err := root.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	defer root.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	if !helperStopped.Load() {
+		t.Fatal("helper should be stopped when the significant child completes")
+	}
+	if err := job.Wait(); err != nil {
+		t.Fatalf("auto shutdown should be a normal exit, got: %v", err)
+	}
+	if reason, _ := jobExit.Load().(ExitReason); reason != ExitNormal {
+		t.Fatalf("parent should see a normal exit, got: %v", reason)
+	}
+	if restarts.get("job") != 0 {
+		t.Fatalf("parent should not restart the job, got %d restarts", restarts.get("job"))
+	}
+}
+
+// TestAutoShutdownAllSignificant tests that the supervisor waits for every significant child
+func TestAutoShutdownAllSignificant(t *testing.T) {
+	finish := func(delay time.Duration) ChildFunc {
+		return func(ctx context.Context) error {
+			time.Sleep(delay)
+			return nil
+		}
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("all-significant-test"),
+		WithAutoShutdown(AutoShutdownAllSignificant),
+		WithChildren(
+			ChildSpec{Name: "fast", Start: finish(20 * time.Millisecond), Restart: Temporary, Significant: true},
+			ChildSpec{Name: "slow", Start: finish(100 * time.Millisecond), Restart: Transient, Significant: true},
+			ChildSpec{Name: "helper", Start: func(ctx context.Context) error { <-ctx.Done(); return nil }, Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := sup.Count(); err != nil {
+		t.Fatalf("supervisor should keep running until all significant children exit, got: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- sup.Wait() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expected a normal exit, got: %v", err)
+		}
+	case <-time.After(time.Second):
+		sup.Stop()
+		t.Fatal("supervisor should shut down once all significant children exit")
+	}
+}
+
+// TestSignificantPermanentRejected tests that a significant child can't be Permanent
+func TestSignificantPermanentRejected(t *testing.T) {
+	sup := New(OneForOne)
+	err := sup.AddChild(ChildSpec{
+		Name:        "main",
+		Start:       func(ctx context.Context) error { return nil },
+		Restart:     Permanent,
+		Significant: true,
+	})
+	if !errors.Is(err, ErrSignificantPermanent) {
+		t.Fatalf("expected ErrSignificantPermanent, got: %v", err)
+	}
+}
+
+// TestUnrecoverableStopsRestarts tests that an Unrecoverable error stops a Permanent child from restarting
+func TestUnrecoverableStopsRestarts(t *testing.T) {
+	var runs atomic.Int32
+	errBadConfig := errors.New("bad config")
+
+	sup := New(
+		OneForOne,
+		WithName("unrecoverable-test"),
+		WithBackoff(ConstantBackoff(0)),
+		WithChildren(
+			ChildSpec{Name: "other", Start: func(ctx context.Context) error { <-ctx.Done(); return nil }, Restart: Permanent},
+			ChildSpec{
+				Name: "worker",
+				Start: func(ctx context.Context) error {
+					runs.Add(1)
+					return Unrecoverable(fmt.Errorf("loading: %w", errBadConfig))
+				},
+				Restart: Permanent,
+			},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	infos, err := sup.Children()
+	if err != nil {
+		t.Fatalf("failed to list children: %v", err)
+	}
+	sup.Stop()
+
+	if runs.Load() != 1 {
+		t.Fatalf("unrecoverable child should run once, got %d runs", runs.Load())
+	}
+	if len(infos) != 1 || infos[0].Name != "other" {
+		t.Fatalf("unrecoverable child should be dropped, got %+v", infos)
+	}
+}
+
+// TestEscalateFailsSupervisor tests that an escalated error fails the supervisor right away
+func TestEscalateFailsSupervisor(t *testing.T) {
+	errCorrupted := errors.New("data corrupted")
+
+	sup := New(
+		OneForOne,
+		WithName("escalate-test"),
+		WithChildren(ChildSpec{
+			Name: "worker",
+			Start: func(ctx context.Context) error {
+				return Escalate(errCorrupted)
+			},
+			Restart: Permanent,
+		}),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	err := sup.Wait()
+	if !errors.Is(err, ErrEscalated) || !errors.Is(err, errCorrupted) {
+		t.Fatalf("expected ErrEscalated wrapping the child's error, got: %v", err)
+	}
+}
+
+// TestClassify tests that child and supervisor classifiers decide which errors are retried
+func TestClassify(t *testing.T) {
+	errBadConfig := errors.New("bad config")
+	errNetwork := errors.New("connection reset")
+	errUnknown := errors.New("unknown")
+
+	var configRuns, networkRuns, unknownRuns atomic.Int32
+	failWith := func(runs *atomic.Int32, err error) ChildFunc {
+		return func(ctx context.Context) error {
+			if runs.Add(1) < 3 {
+				return err
+			}
+			<-ctx.Done()
+			return nil
+		}
+	}
+
+	classify := func(err error) Decision {
+		switch {
+		case errors.Is(err, errBadConfig):
+			return DecisionStop
+		case errors.Is(err, errNetwork):
+			return DecisionRestart
+		default:
+			return DecisionDefault
+		}
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("classify-test"),
+		WithBackoff(ConstantBackoff(0)),
+		WithClassifier(func(err error) Decision { return DecisionStop }),
+		WithChildren(
+			ChildSpec{Name: "other", Start: func(ctx context.Context) error { <-ctx.Done(); return nil }, Restart: Permanent},
+			ChildSpec{Name: "config", Start: failWith(&configRuns, errBadConfig), Restart: Permanent, Classify: classify},
+			ChildSpec{Name: "network", Start: failWith(&networkRuns, errNetwork), Restart: Temporary, Classify: classify},
+			ChildSpec{Name: "unknown", Start: failWith(&unknownRuns, errUnknown), Restart: Permanent, Classify: classify},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	sup.Stop()
+
+	if configRuns.Load() != 1 {
+		t.Errorf("config errors should not be retried, got %d runs", configRuns.Load())
+	}
+	if networkRuns.Load() != 3 {
+		t.Errorf("network errors should be retried even for a Temporary child, got %d runs", networkRuns.Load())
+	}
+	if unknownRuns.Load() != 1 {
+		t.Errorf("the supervisor classifier should decide for unknown errors, got %d runs", unknownRuns.Load())
+	}
+}
+
+// TestEventDetails tests that events identify their supervisor and carry restart details
+func TestEventDetails(t *testing.T) {
+	var mu sync.Mutex
+	var events []Event
+	record := func(e Event) {
+		mu.Lock()
+		events = append(events, e)
+		mu.Unlock()
+	}
+
+	var runs atomic.Int32
+	worker := func(ctx context.Context) error {
+		if runs.Add(1) == 1 {
+			time.Sleep(20 * time.Millisecond)
+			return errors.New("boom")
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	sub := New(
+		RestForOne,
+		WithName("sub"),
+		WithBackoff(ConstantBackoff(30*time.Millisecond)),
+		WithEventHandler(record),
+		WithChildren(ChildSpec{Name: "worker", Start: worker, Restart: Permanent}),
+	)
+	root := New(
+		OneForOne,
+		WithName("root"),
+		WithChildren(ChildSpec{Name: "sub", Supervisor: sub, Restart: Permanent, Shutdown: ShutdownInfinity}),
+	)
+
+	if err := root.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	root.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	find := func(et EventType) Event {
+		for _, e := range events {
+			if e.Type == et {
+				return e
+			}
+		}
+		t.Fatalf("no %v event in %v", et, events)
+		return Event{}
+	}
+
+	for _, e := range events {
+		if e.Supervisor != "sub" || e.Path != "root/sub" {
+			t.Fatalf("expected events from root/sub, got %q at %q", e.Supervisor, e.Path)
+		}
+	}
+
+	if exited := find(ChildExited); exited.Uptime < 20*time.Millisecond || exited.Attempt != 1 {
+		t.Fatalf("expected exit of attempt 1 after at least 20ms, got attempt %d after %v", exited.Attempt, exited.Uptime)
+	}
+	if backoff := find(BackoffScheduled); backoff.Delay != 30*time.Millisecond || backoff.Strategy != "RestForOne" {
+		t.Fatalf("expected a 30ms RestForOne backoff, got %v with %q", backoff.Delay, backoff.Strategy)
+	}
+	if restarted := find(ChildRestarted); restarted.Attempt != 2 || restarted.Restarts != 1 || restarted.Strategy != "RestForOne" {
+		t.Fatalf("expected attempt 2 after 1 restart by RestForOne, got %+v", restarted)
+	}
+	if stopped := find(ChildStopped); stopped.Reason != ExitShutdown || stopped.Attempt != 2 {
+		t.Fatalf("expected attempt 2 to be stopped on shutdown, got %+v", stopped)
+	}
+}
+
+// TestSlowEventHandler tests that a slow handler doesn't hold up restarts and drops events when full
+func TestSlowEventHandler(t *testing.T) {
+	var runs atomic.Int32
+	worker := func(ctx context.Context) error {
+		if runs.Add(1) < 10 {
+			return errors.New("crash")
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	release := make(chan struct{})
+	sup := New(
+		OneForOne,
+		WithName("slow-handler-test"),
+		WithBackoff(ConstantBackoff(0)),
+		WithEventBuffer(2, OverflowDropNewest),
+		WithEventHandler(func(e Event) { <-release }),
+		WithChildren(ChildSpec{Name: "worker", Start: worker, Restart: Permanent}),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if runs.Load() != 10 {
+		t.Fatalf("restarts should not wait for the handler, got %d runs", runs.Load())
+	}
+	stats := sup.EventStats()
+	if len(stats) != 1 || stats[0].Dropped == 0 || stats[0].Queued != 2 {
+		t.Fatalf("expected a full queue and dropped events, got %+v", stats)
+	}
+
+	close(release)
+	sup.Stop()
+}
+
+// TestEventOverflowDropOldest tests that the oldest queued events make room for new ones
+func TestEventOverflowDropOldest(t *testing.T) {
+	d := &dispatcher{policy: OverflowDropOldest, queue: make(chan Event, 2)}
+	for i := range 5 {
+		d.send(Event{Attempt: i})
+	}
+
+	if got := d.dropped.Load(); got != 3 {
+		t.Fatalf("expected 3 dropped events, got %d", got)
+	}
+	if first, second := <-d.queue, <-d.queue; first.Attempt != 3 || second.Attempt != 4 {
+		t.Fatalf("expected the newest events to be kept, got attempts %d and %d", first.Attempt, second.Attempt)
+	}
+}
+
+// TestEventHandlerPanic tests that a panicking handler is reported and doesn't stop the supervisor
+func TestEventHandlerPanic(t *testing.T) {
+	var panicked atomic.Value
+	var started atomic.Int32
+
+	sup := New(
+		OneForOne,
+		WithName("handler-panic-test"),
+		WithEventHandler(func(e Event) {
+			if e.Type == ChildStarted {
+				panic("handler bug")
+			}
+		}),
+		WithEventHandler(func(e Event) {
+			switch e.Type {
+			case HandlerPanicked:
+				panicked.Store(e)
+			case ChildStarted:
+				started.Add(1)
+			}
+		}),
+		WithChildren(ChildSpec{Name: "worker", Start: func(ctx context.Context) error { <-ctx.Done(); return nil }, Restart: Permanent}),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	if err := sup.AddChild(ChildSpec{Name: "late", Start: func(ctx context.Context) error { <-ctx.Done(); return nil }}); err != nil {
+		t.Fatalf("supervisor should survive a panicking handler, got: %v", err)
+	}
+	if err := sup.Stop(); err != nil {
+		t.Fatalf("unexpected stop error: %v", err)
+	}
+
+	e, ok := panicked.Load().(Event)
+	if !ok || !strings.Contains(e.Err.Error(), "handler bug") || e.StackTrace == "" {
+		t.Fatalf("expected a HandlerPanicked event with a stack trace, got %+v", e)
+	}
+	if started.Load() != 2 {
+		t.Fatalf("other handlers should keep receiving events, got %d starts", started.Load())
+	}
+	if stats := sup.EventStats(); stats[0].Panics != 2 || stats[1].Panics != 0 {
+		t.Fatalf("expected 2 panics in the first handler only, got %+v", stats)
+	}
+}
+
+// TestSubscribe tests waiting for a specific event from a nested supervisor through a subscription
+func TestSubscribe(t *testing.T) {
+	crash := make(chan struct{})
+	var crashed atomic.Bool
+	worker := func(ctx context.Context) error {
+		if ChildName(ctx) == "worker-3" && !crashed.Load() {
+			select {
+			case <-crash:
+				crashed.Store(true)
+				return errors.New("crash")
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	pool := New(
+		SimpleOneForOne,
+		WithName("pool"),
+		WithBackoff(ConstantBackoff(0)),
+		WithTemplate(ChildSpec{Name: "worker", Start: worker, Restart: Transient}),
+	)
+	root := New(
+		OneForOne,
+		WithName("root"),
+		WithChildren(ChildSpec{Name: "pool", Supervisor: pool, Restart: Permanent, Shutdown: ShutdownInfinity}),
+	)
+
+	// Subscriptions can be made before Start
+	all, cancelAll := root.Subscribe(EventFilter{})
+	defer cancelAll()
+
+	if err := root.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	defer root.Stop()
+
+	for range 4 {
+		if _, err := pool.StartChild(nil); err != nil {
+			t.Fatalf("failed to start child: %v", err)
+		}
+	}
+
+	restarts, cancel := root.Subscribe(EventFilter{
+		Types:   []EventType{ChildRestarted},
+		Child:   "worker-3",
+		Subtree: "root/pool",
+	})
+	defer cancel()
+
+	close(crash)
+
+	select {
+	case e := <-restarts:
+		if e.ChildName != "worker-3" || e.Path != "root/pool" || e.Type != ChildRestarted {
+			t.Fatalf("unexpected event: %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for worker-3 to restart")
+	}
+
+	cancel()
+	if _, ok := <-restarts; ok {
+		t.Fatal("cancel should close the channel")
+	}
+	cancel()
+
+	// The unfiltered subscription saw the root's own events too
+	if e := <-all; e.Type != ChildStarted || e.ChildName != "pool" || e.Path != "root" {
+		t.Fatalf("expected the root to start the pool first, got %+v", e)
+	}
+}
+
+// TestEventFilter tests matching events by type, child name pattern and subtree
+func TestEventFilter(t *testing.T) {
+	e := Event{Type: ChildRestarted, ChildName: "worker-3", Path: "app/pool"}
+
+	tests := []struct {
+		filter  EventFilter
+		matches bool
+	}{
+		{EventFilter{}, true},
+		{EventFilter{Types: []EventType{ChildStarted, ChildRestarted}}, true},
+		{EventFilter{Types: []EventType{ChildStarted}}, false},
+		{EventFilter{Child: "worker-*"}, true},
+		{EventFilter{Child: "db"}, false},
+		{EventFilter{Subtree: "app"}, true},
+		{EventFilter{Subtree: "app/pool"}, true},
+		{EventFilter{Subtree: "app/po"}, false},
+		{EventFilter{Subtree: "other"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.filter.matches(e); got != tt.matches {
+			t.Errorf("%+v: expected %v, got %v", tt.filter, tt.matches, got)
+		}
+	}
+}
+
+// TestJournalHistory tests looking up recent crashes in the journal after the fact
+func TestJournalHistory(t *testing.T) {
+	var runs atomic.Int32
+	worker := func(ctx context.Context) error {
+		if runs.Add(1) <= 2 {
+			panic("boom")
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	sup := New(
+		OneForOne,
+		WithName("journal-test"),
+		WithJournal(100),
+		WithBackoff(ConstantBackoff(0)),
+		WithChildren(
+			ChildSpec{Name: "steady", Start: func(ctx context.Context) error { <-ctx.Done(); return nil }, Restart: Permanent},
+			ChildSpec{Name: "crashy", Start: worker, Restart: Permanent},
+		),
+	)
+
+	before := time.Now()
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	sup.Stop()
+
+	// The journal outlives the supervisor
+	crashes := sup.History(HistoryQuery{Types: []EventType{ChildPanicked}, Since: before})
+	if len(crashes) != 2 {
+		t.Fatalf("expected 2 crash reports, got %d", len(crashes))
+	}
+	for _, e := range crashes {
+		if e.ChildName != "crashy" || !strings.Contains(e.StackTrace, "panic") {
+			t.Fatalf("expected crashy's panic with a stack trace, got %+v", e)
+		}
+	}
+	if crashes[0].Attempt != 1 || crashes[1].Attempt != 2 {
+		t.Fatalf("expected crash reports oldest first, got attempts %d and %d", crashes[0].Attempt, crashes[1].Attempt)
+	}
+
+	if steady := sup.History(HistoryQuery{Child: "steady"}); len(steady) != 2 || steady[0].Type != ChildStarted || steady[1].Type != ChildStopped {
+		t.Fatalf("expected steady to start and stop, got %v", steady)
+	}
+	if future := sup.History(HistoryQuery{Since: time.Now()}); len(future) != 0 {
+		t.Fatalf("expected no events after now, got %d", len(future))
+	}
+	if old := sup.History(HistoryQuery{Until: before}); len(old) != 0 {
+		t.Fatalf("expected no events before start, got %d", len(old))
+	}
+}
+
+// TestJournalKeepsLastEvents tests that the journal keeps only the most recent events
+func TestJournalKeepsLastEvents(t *testing.T) {
+	j := newJournal(3)
+	for i := range 5 {
+		j.add(Event{Attempt: i})
+	}
+
+	events := j.query(HistoryQuery{})
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+	for i, e := range events {
+		if e.Attempt != i+2 {
+			t.Fatalf("expected attempts 2, 3, 4 in order, got %v", events)
+		}
+	}
+
+	if sup := New(OneForOne); sup.History(HistoryQuery{}) != nil {
+		t.Fatal("expected no history without a journal")
+	}
+}
+
+// TestReturnedRunContextCanceled tests that the context of a run that returned on
+// its own is canceled, so goroutines started on it don't outlive it
+func TestReturnedRunContextCanceled(t *testing.T) {
+	var runs, canceled atomic.Int32
+	worker := func(ctx context.Context) error {
+		go func() {
+			<-ctx.Done()
+			canceled.Add(1)
+		}()
+		if runs.Add(1) <= 5 {
+			return errors.New("crash")
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	sup := New(
+		OneForOne,
+		WithIntensity(10, time.Second),
+		WithBackoff(ConstantBackoff(0)),
+		WithChildren(ChildSpec{Name: "worker", Start: worker, Restart: Permanent}),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	if got := canceled.Load(); got != 5 {
+		t.Fatalf("expected the 5 crashed runs to be canceled, got %d", got)
+	}
+
+	sup.Stop()
+	time.Sleep(10 * time.Millisecond)
+
+	if got, want := canceled.Load(), runs.Load(); got != want {
+		t.Fatalf("expected all %d runs to be canceled after Stop, got %d", want, got)
+	}
+}
+
+// TestChildRemovedEvent tests that dropped and removed children are reported
+func TestChildRemovedEvent(t *testing.T) {
+	block := func(ctx context.Context) error { <-ctx.Done(); return nil }
+
+	sup := New(
+		OneForOne,
+		WithJournal(100),
+		WithChildren(
+			ChildSpec{Name: "steady", Start: block, Restart: Permanent},
+			ChildSpec{Name: "oneshot", Start: func(ctx context.Context) error { return nil }, Restart: Temporary},
+			ChildSpec{Name: "removed", Start: block, Restart: Permanent},
+		),
+	)
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+	defer sup.Stop()
+	time.Sleep(20 * time.Millisecond)
+
+	if err := sup.RemoveChild("removed"); err != nil {
+		t.Fatalf("failed to remove child: %v", err)
+	}
+
+	var removed []string
+	for _, e := range sup.History(HistoryQuery{Types: []EventType{ChildRemoved}}) {
+		removed = append(removed, e.ChildName)
+	}
+	if !slices.Equal(removed, []string{"oneshot", "removed"}) {
+		t.Fatalf("expected oneshot and removed to be reported, got %v", removed)
+	}
+}
+
+// TestConcurrentOperations tests thread safety
+func TestConcurrentOperations(t *testing.T) {
+	worker := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+
+	sup := New(OneForOne, WithName("concurrent-test"))
+
+	if err := sup.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	// Add children concurrently
+	done := make(chan bool)
+	for i := 0; i < 10; i++ {
+		go func(id int) {
+			_ = sup.AddChild(ChildSpec{
+				Name:    "worker-" + string(rune('0'+id)),
+				Start:   worker,
+				Restart: Permanent,
+			})
+			done <- true
+		}(i)
+	}
+
+	for i := 0; i < 10; i++ {
+		<-done
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	sup.Stop()
+}
+
+// TestNestedSupervisorStopsWithParent tests that stopping the root stops a nested supervisor's children
+func TestNestedSupervisorStopsWithParent(t *testing.T) {
+	var running atomic.Int32
+
+	worker := func(ctx context.Context) error {
+		running.Add(1)
+		defer running.Add(-1)
+		<-ctx.Done()
+		return nil
+	}
+
+	sub := New(
+		OneForOne,
+		WithName("sub"),
+		WithChildren(
+			ChildSpec{Name: "sub-worker-1", Start: worker, Restart: Permanent},
+			ChildSpec{Name: "sub-worker-2", Start: worker, Restart: Permanent},
+		),
+	)
+
+	root := New(
+		OneForOne,
+		WithName("root"),
+		WithChildren(
+			ChildSpec{Name: "sub", Supervisor: sub, Restart: Permanent},
+		),
+	)
+
+	if err := root.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if running.Load() != 2 {
+		t.Fatalf("expected 2 running sub workers, got %d", running.Load())
+	}
+
+	if err := root.Stop(); err != nil {
+		t.Fatalf("failed to stop supervisor: %v", err)
+	}
+
+	if err := sub.Wait(); err != nil {
+		t.Fatalf("unexpected error from nested supervisor: %v", err)
+	}
+
+	if running.Load() != 0 {
+		t.Fatalf("nested workers still running after root stopped: %d", running.Load())
+	}
+}
+
+// TestNestedSupervisorIntensityFailure tests that a nested supervisor exceeding its intensity fails in the parent
+func TestNestedSupervisorIntensityFailure(t *testing.T) {
+	var subRuns atomic.Int32
+	var subFailure atomic.Value
+
+	sub := New(
+		OneForOne,
+		WithName("flaky-sub"),
+		WithIntensity(1, time.Second),
+		WithBackoff(ConstantBackoff(time.Millisecond)),
+		WithChildren(
+			ChildSpec{
+				Name: "failing-worker",
+				Start: func(ctx context.Context) error {
+					return errors.New("always fails")
+				},
+				Restart: Permanent,
+			},
+		),
+	)
+
+	root := New(
+		OneForOne,
+		WithName("root"),
+		WithIntensity(2, time.Second),
+		WithBackoff(ConstantBackoff(time.Millisecond)),
+		WithEventHandler(func(e Event) {
+			if e.ChildName != "sub" {
+				return
+			}
+			switch e.Type {
+			case ChildStarted:
+				subRuns.Add(1)
+			case ChildExited:
+				if e.Err != nil {
+					subFailure.Store(e.Err)
+				}
+			}
+		}),
+		WithChildren(
+			ChildSpec{Name: "sub", Supervisor: sub, Restart: Permanent},
+		),
+	)
+
+	if err := root.Start(); err != nil {
+		t.Fatalf("failed to start supervisor: %v", err)
+	}
+
+	err := root.Wait()
+	if !errors.Is(err, ErrIntensityExceeded) {
+		t.Fatalf("expected ErrIntensityExceeded, got: %v", err)
+	}
+
+	failure, _ := subFailure.Load().(error)
+	if !errors.Is(failure, ErrIntensityExceeded) {
+		t.Fatalf("expected nested failure to wrap ErrIntensityExceeded, got: %v", failure)
+	}
+
+	// The parent restarts the nested supervisor until its own limit is hit.
+	if subRuns.Load() < 2 {
+		t.Fatalf("nested supervisor should have been restarted, runs: %d", subRuns.Load())
+	}
+}
+
+// TestInvalidChildSpec tests that specs must set exactly one of Start or Supervisor
+func TestInvalidChildSpec(t *testing.T) {
+	sup := New(OneForOne, WithName("invalid-spec-test"))
+
+	if err := sup.AddChild(ChildSpec{Name: "nothing"}); !errors.Is(err, ErrInvalidChildSpec) {
+		t.Fatalf("expected ErrInvalidChildSpec, got: %v", err)
+	}
+
+	err := sup.AddChild(ChildSpec{
+		Name:       "both",
+		Start:      func(ctx context.Context) error { return nil },
+		Supervisor: New(OneForOne),
+	})
+	if !errors.Is(err, ErrInvalidChildSpec) {
+		t.Fatalf("expected ErrInvalidChildSpec, got: %v", err)
+	}
+}
+
 // BenchmarkSupervisorOverhead benchmarks supervisor overhead
 func BenchmarkSupervisorOverhead(b *testing.B) {
 	worker := func(ctx context.Context) error {
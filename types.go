@@ -1,6 +1,10 @@
 package goverseer
 
-import "context"
+import (
+	"context"
+	"math"
+	"time"
+)
 
 // ChildFunc is the function signature for a supervised child process.
 // The function receives a context that will be canceled when the supervisor
@@ -39,9 +43,183 @@ type ChildSpec struct {
 	// It receives a context that will be canceled when the child should stop.
 	Start ChildFunc
 
+	// Supervisor runs a nested supervisor as this child instead of Start.
+	// The nested supervisor is started with a context derived from the parent,
+	// is stopped when the parent shuts down, and exceeding its intensity limit
+	// counts as a child failure in the parent. It must not be started on its own.
+	Supervisor *Supervisor
+
+	// DependsOn names the children this child needs. Children are started after
+	// the children they depend on and stopped before them, and when a child fails,
+	// the children that depend on it (directly or transitively) are restarted with
+	// it, whatever the strategy. Start returns ErrMissingDependency or
+	// ErrDependencyCycle if the dependencies don't form a DAG.
+	DependsOn []string
+
 	// Restart determines when this child should be restarted after exit.
 	// - Permanent: Always restart (use for critical services)
 	// - Transient: Restart only on error/panic (use for retriable tasks)
 	// - Temporary: Never restart (use for one-off tasks)
 	Restart RestartType
+
+	// Significant marks a child whose completion can shut the supervisor down
+	// (see WithAutoShutdown). Significant children must be Transient or Temporary.
+	Significant bool
+
+	// Classify optionally decides what to do when the child exits with an error
+	// (including panics), e.g. stop retrying configuration errors but keep
+	// retrying network errors. Returning DecisionDefault defers to the
+	// supervisor's classifier (WithClassifier), then to Restart.
+	Classify func(err error) Decision
+
+	// Shutdown is how long the child is given to return after its context is canceled,
+	// whether it is being removed, restarted, or the supervisor is shutting down.
+	// Zero uses the supervisor's WithShutdownTimeout. Use ShutdownBrutalKill to stop
+	// waiting immediately, or ShutdownInfinity to wait as long as it takes
+	// (typically for nested supervisors, which bound their own shutdown).
+	Shutdown time.Duration
+
+	// Intensity optionally limits how often this child may be restarted.
+	// The supervisor-wide limit (WithIntensity) still applies on top of it.
+	Intensity *Intensity
+
+	// Backoff optionally overrides the supervisor's backoff policy (WithBackoff)
+	// for this child.
+	Backoff BackoffPolicy
+
+	// StableAfter optionally overrides the supervisor's stable-after duration
+	// (WithStableAfter): once the child has been running this long, its restart
+	// count and backoff reset.
+	StableAfter time.Duration
+
+	// StartTimeout opts the child into the readiness handshake: after starting it,
+	// the supervisor waits up to StartTimeout for the child to call Ready before
+	// starting the next child. Start and AddChild return ErrStartTimeout or
+	// ErrExitedBeforeReady if it doesn't; on restarts the child counts as failed.
+	// A nested supervisor is ready once all of its own children have started.
+	// Zero (the default) doesn't wait.
+	StartTimeout time.Duration
+
+	// HealthCheck optionally probes a running child, e.g. by pinging its server.
+	// It is called every HealthInterval with a context bounded by HealthTimeout;
+	// after HealthFailureThreshold consecutive failures the child is stopped and
+	// treated as failed with ErrUnhealthy, so it is restarted by the strategy.
+	// Probing starts once the child is ready (see StartTimeout).
+	HealthCheck func(ctx context.Context) error
+
+	// HealthInterval is how often HealthCheck runs. Zero means 10 seconds.
+	HealthInterval time.Duration
+
+	// HealthTimeout bounds each HealthCheck call. Zero means HealthInterval.
+	HealthTimeout time.Duration
+
+	// HealthFailureThreshold is how many consecutive HealthCheck failures make
+	// the child unhealthy. Zero means 3.
+	HealthFailureThreshold int
+
+	// WatchdogInterval turns on the heartbeat watchdog: the child must call
+	// Heartbeat at least this often, or it is considered hung. A hung child is
+	// canceled with ErrChildHung and, like any failed child, restarted by the
+	// strategy; if it doesn't return within its Shutdown budget its goroutine is
+	// abandoned. The watchdog starts once the child is ready. Zero disables it.
+	WatchdogInterval time.Duration
+
+	// args holds the arguments of a SimpleOneForOne instance started with StartChild.
+	args any
+}
+
+const (
+	// ShutdownBrutalKill cancels the child and does not wait for it to return.
+	// Go cannot kill a goroutine, so the child is simply abandoned.
+	ShutdownBrutalKill time.Duration = -1
+
+	// ShutdownInfinity waits for the child to return, however long that takes.
+	ShutdownInfinity time.Duration = math.MaxInt64
+)
+
+// Intensity is a per-child restart limit: at most MaxRestarts restarts within Window.
+//
+// Example:
+//
+//	goverseer.ChildSpec{
+//	    Name:      "flaky-importer",
+//	    Start:     importer,
+//	    Restart:   goverseer.Permanent,
+//	    Intensity: &goverseer.Intensity{MaxRestarts: 3, Window: time.Minute},
+//	    Backoff:   goverseer.ConstantBackoff(10 * time.Second),
+//	}
+type Intensity struct {
+	// MaxRestarts is the number of restarts allowed within Window.
+	MaxRestarts int
+	// Window is the sliding time window restarts are counted in.
+	Window time.Duration
+	// OnExceeded decides what happens when the child goes over its limit.
+	// The default, IntensityDrop, stops restarting the child.
+	OnExceeded IntensityPolicy
+}
+
+// IntensityPolicy decides what happens when a child exceeds its own Intensity.
+type IntensityPolicy int
+
+const (
+	// IntensityDrop removes the child from the supervisor; its siblings keep running.
+	IntensityDrop IntensityPolicy = iota
+
+	// IntensityEscalate fails the whole supervisor with ErrIntensityExceeded,
+	// as if the supervisor-wide limit had been exceeded.
+	IntensityEscalate
+)
+
+// String returns the string representation of an IntensityPolicy.
+func (p IntensityPolicy) String() string {
+	switch p {
+	case IntensityDrop:
+		return "Drop"
+	case IntensityEscalate:
+		return "Escalate"
+	default:
+		return "Unknown"
+	}
+}
+
+// AutoShutdown decides whether the exit of significant children shuts the
+// supervisor down, like OTP's auto_shutdown.
+type AutoShutdown int
+
+const (
+	// AutoShutdownNever ignores the Significant flag. This is the default.
+	AutoShutdownNever AutoShutdown = iota
+
+	// AutoShutdownAnySignificant shuts the supervisor down as soon as any
+	// significant child exits without being restarted.
+	AutoShutdownAnySignificant
+
+	// AutoShutdownAllSignificant shuts the supervisor down once all significant
+	// children have exited without being restarted.
+	AutoShutdownAllSignificant
+)
+
+// String returns the string representation of an AutoShutdown.
+func (a AutoShutdown) String() string {
+	switch a {
+	case AutoShutdownNever:
+		return "Never"
+	case AutoShutdownAnySignificant:
+		return "AnySignificant"
+	case AutoShutdownAllSignificant:
+		return "AllSignificant"
+	default:
+		return "Unknown"
+	}
+}
+
+// validate checks that the spec describes something runnable.
+func (spec ChildSpec) validate() error {
+	if (spec.Start == nil) == (spec.Supervisor == nil) {
+		return ErrInvalidChildSpec
+	}
+	if spec.Significant && spec.Restart == Permanent {
+		return ErrSignificantPermanent
+	}
+	return nil
 }